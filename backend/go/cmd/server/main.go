@@ -11,42 +11,113 @@ probabilities.
 
 Usage:
     go run cmd/server/main.go
+    go run cmd/server/main.go -cpuprofile cpu.prof -memprofile mem.prof
 
 The server runs on port 8000 by default.
 */
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
 	"log"
 	"math/rand"
+	"net/http"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime/pprof"
+	"syscall"
 	"time"
 
 	"github.com/winzerprince/menace/backend/go/internal/api"
+	"github.com/winzerprince/menace/backend/go/pkg/config"
+	"github.com/winzerprince/menace/backend/go/pkg/store"
 )
 
+// shutdownTimeout bounds how long main waits for active games to finish
+// once a shutdown signal arrives, before shutting the server down anyway.
+const shutdownTimeout = 10 * time.Second
+
 func main() {
+	cpuprofile := flag.String("cpuprofile", "", "write CPU profile to file")
+	memprofile := flag.String("memprofile", "", "write memory profile to file")
+	flag.Parse()
+
 	// Seed random number generator
 	rand.Seed(time.Now().UnixNano())
 
-	// Get port from environment or use default
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8000"
+	if *cpuprofile != "" {
+		f, err := os.Create(*cpuprofile)
+		if err != nil {
+			log.Fatalf("Failed to create CPU profile: %v", err)
+		}
+		defer f.Close()
+		if err := pprof.StartCPUProfile(f); err != nil {
+			log.Fatalf("Failed to start CPU profile: %v", err)
+		}
+		defer pprof.StopCPUProfile()
+	}
+
+	// Load config: built-in defaults, layered with ~/.config/menace/config.json
+	// and environment variables, if present.
+	cfg, err := config.LoadConfig("")
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
 	}
 
 	// Create handler with MENACE instance
-	handler := api.NewHandler()
+	handler := api.NewHandler(cfg)
 
 	// Setup router
-	router := api.SetupRouter(handler)
+	router := api.SetupRouter(handler, cfg)
+
+	srv := &http.Server{
+		Addr:    ":" + cfg.Port,
+		Handler: router,
+	}
+
+	go func() {
+		fmt.Printf("🎮 MENACE Go Backend starting on port %s\n", cfg.Port)
+		fmt.Printf("📚 API docs: http://localhost:%s/api/health\n", cfg.Port)
+
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("Failed to start server: %v", err)
+		}
+	}()
 
-	// Start server
-	fmt.Printf("🎮 MENACE Go Backend starting on port %s\n", port)
-	fmt.Printf("📚 API docs: http://localhost:%s/api/health\n", port)
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	<-sigCh
 
-	if err := router.Run(":" + port); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	fmt.Println("🛑 Shutting down: no longer accepting new games")
+	if drained := handler.Shutdown(shutdownTimeout); !drained {
+		fmt.Println("⚠️  Timed out waiting for active games to finish")
 	}
+
+	if err := os.MkdirAll(filepath.Dir(cfg.MatchboxStorePath), 0o755); err != nil {
+		log.Printf("Failed to create matchbox store directory: %v", err)
+	} else if err := store.NewJSONStore(cfg.MatchboxStorePath).Save(handler.Menace().SnapshotForSave()); err != nil {
+		log.Printf("Failed to persist matchboxes on shutdown: %v", err)
+	}
+
+	if *memprofile != "" {
+		f, err := os.Create(*memprofile)
+		if err != nil {
+			log.Fatalf("Failed to create memory profile: %v", err)
+		}
+		defer f.Close()
+		if err := pprof.WriteHeapProfile(f); err != nil {
+			log.Fatalf("Failed to write memory profile: %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Fatalf("Failed to shut down server: %v", err)
+	}
+
+	fmt.Println("✅ Server stopped")
 }
@@ -4,34 +4,89 @@ Package api provides the HTTP API handlers for the MENACE backend.
 package api
 
 import (
+	"errors"
 	"fmt"
 	"math/rand"
 	"net/http"
+	"sort"
 	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/winzerprince/menace/backend/go/pkg/board"
+	"github.com/winzerprince/menace/backend/go/pkg/config"
 	"github.com/winzerprince/menace/backend/go/pkg/game"
 	"github.com/winzerprince/menace/backend/go/pkg/menace"
+	"github.com/winzerprince/menace/backend/go/pkg/opponent"
+	"github.com/winzerprince/menace/backend/go/pkg/stream"
 )
 
 // Handler contains the API handlers and dependencies
 type Handler struct {
 	menace      *menace.Menace
 	gameManager *game.GameManager
+	lobby       *game.Lobby
+	hub         *stream.Hub
+	cfg         config.Config
+
+	stopJanitor func()
 }
 
-// NewHandler creates a new API handler
-func NewHandler() *Handler {
+// janitorInterval is how often NewHandler's background janitor checks for
+// idle games to evict. It's independent of GameSessionTTL, which is how
+// long a game has to sit idle before it's evicted.
+const janitorInterval = 1 * time.Minute
+
+// NewHandler creates a new API handler, applying cfg's reward and bead
+// parameters to the Menace instance it creates, and starts the background
+// janitor that evicts games idle longer than cfg.GameSessionTTL.
+func NewHandler(cfg config.Config) *Handler {
 	m := menace.NewMenace(board.PlayerX)
+	m.InitialBeads = cfg.InitialBeadsPerMove
+	m.WinReward = cfg.RewardWin
+	m.DrawReward = cfg.RewardDraw
+	m.LossPenalty = cfg.RewardLoss
+	m.MinBeads = cfg.MinBeads
+
 	gm := game.NewGameManager(m)
+	hub := stream.NewHub()
+	lobby := game.NewLobby(gm)
+
+	m.AttachEventBus(hub)
+	gm.AttachEventBus(hub)
+
+	stopGameJanitor := gm.StartJanitor(time.Duration(cfg.GameSessionTTL), janitorInterval)
+	stopLobbyJanitor := lobby.StartJanitor(time.Duration(cfg.GameSessionTTL), janitorInterval)
+
 	return &Handler{
 		menace:      m,
 		gameManager: gm,
+		lobby:       lobby,
+		hub:         hub,
+		cfg:         cfg,
+		stopJanitor: func() {
+			stopGameJanitor()
+			stopLobbyJanitor()
+		},
 	}
 }
 
+// Menace returns the Handler's Menace instance, so cmd/server can persist
+// it to a MatchboxStore during graceful shutdown.
+func (h *Handler) Menace() *menace.Menace {
+	return h.menace
+}
+
+// Shutdown stops the janitor, stops accepting new games, and waits up to
+// timeout for active games to finish. It returns false if games were
+// still active when timeout elapsed.
+func (h *Handler) Shutdown(timeout time.Duration) bool {
+	h.stopJanitor()
+	h.gameManager.StopAccepting()
+	return h.gameManager.WaitForActiveGames(timeout)
+}
+
 // ============================================================================
 // Request/Response Types
 // ============================================================================
@@ -52,6 +107,11 @@ type NewGameResponse struct {
 
 type MoveRequest struct {
 	Position int `json:"position" binding:"min=0,max=8"`
+
+	// PlayerToken identifies the caller in a vs_human game, matched
+	// against Game.PlayerX/PlayerO to decide which symbol they're moving
+	// as. Ignored for vs_menace games.
+	PlayerToken string `json:"player_token"`
 }
 
 type MoveResponse struct {
@@ -103,6 +163,12 @@ type MatchboxQueryRequest struct {
 type TrainingRequest struct {
 	NumGames int    `json:"num_games" binding:"min=1,max=5000000"`
 	Opponent string `json:"opponent"`
+
+	// Reward overrides for this run only. Omitted fields keep MENACE's
+	// currently configured reward magnitudes.
+	RewardWin  *int `json:"reward_win"`
+	RewardDraw *int `json:"reward_draw"`
+	RewardLoss *int `json:"reward_loss"`
 }
 
 type TrainingResponse struct {
@@ -115,6 +181,17 @@ type TrainingResponse struct {
 	GamesPerSecond   float64 `json:"games_per_second"`
 	TotalMatchboxes  int     `json:"total_matchboxes"`
 	EstimatedDBSizeKB float64 `json:"estimated_db_size_kb"`
+	// OpponentBreakdown reports MENACE's win/loss/draw record against each
+	// opponent style it actually faced, keyed by trainingOpponent.Name() -
+	// useful for "mixed" and "curriculum" modes where that varies per game.
+	OpponentBreakdown map[string]*OpponentStats `json:"opponent_breakdown"`
+}
+
+// OpponentStats is MENACE's win/loss/draw record against one opponent style.
+type OpponentStats struct {
+	Wins   int `json:"wins"`
+	Losses int `json:"losses"`
+	Draws  int `json:"draws"`
 }
 
 type TrainingEstimateRequest struct {
@@ -178,7 +255,7 @@ func getResultType(g *game.Game) *string {
 }
 
 func getWinner(g *game.Game) *string {
-	winner := g.Board.CheckWinner()
+	winner := g.GetBoard().CheckWinner()
 	if winner == board.PlayerNone {
 		return nil
 	}
@@ -202,6 +279,10 @@ func (h *Handler) NewGame(c *gin.Context) {
 	}
 
 	g := h.gameManager.CreateGame(req.MenacePlaysFirst)
+	if g == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "server is shutting down, not accepting new games"})
+		return
+	}
 
 	var menaceMove *int
 
@@ -213,11 +294,11 @@ func (h *Handler) NewGame(c *gin.Context) {
 		}
 	}
 
-	currentTurn := getPlayerSymbol(g.CurrentTurn)
+	currentTurn := getPlayerSymbol(g.GetCurrentTurn())
 
 	c.JSON(http.StatusOK, NewGameResponse{
 		GameID:       g.ID,
-		Board:        g.Board.State(),
+		Board:        g.GetBoard().State(),
 		CurrentTurn:  currentTurn,
 		MenacePlayer: getPlayerSymbol(g.MenacePlayer),
 		Status:       getGameStatus(g),
@@ -226,79 +307,139 @@ func (h *Handler) NewGame(c *gin.Context) {
 	})
 }
 
-// MakeMove processes a move
-// POST /api/game/:id/move
-func (h *Handler) MakeMove(c *gin.Context) {
-	gameID := c.Param("id")
+// Errors returned by applyOpponentMove, surfaced as 400s by MakeMove and
+// silently dropped by the WebSocket move path.
+var (
+	errGameOver        = errors.New("game is already over")
+	errNotYourTurn     = errors.New("it's not your turn - waiting for MENACE")
+	errInvalidPosition = errors.New("invalid move: position is not available")
+	errInvalidToken    = errors.New("player_token does not hold a seat in this game")
+)
 
-	g := h.gameManager.GetGame(gameID)
-	if g == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Game not found: " + gameID})
-		return
+// applyOpponentMove plays the opponent's move on g, lets MENACE respond if
+// it's now MENACE's turn, and finishes the game in gameManager if it's now
+// over. It's shared by the REST MakeMove handler and the WebSocket game
+// handler so both paths apply a move, and publish the resulting stream
+// events, identically.
+func (h *Handler) applyOpponentMove(g *game.Game, position int) (menaceMove *int, err error) {
+	if g.IsOver() {
+		return nil, errGameOver
+	}
+	if !g.IsOpponentTurn() {
+		return nil, errNotYourTurn
+	}
+
+	validMoves := g.GetValidMoves()
+	isValid := false
+	for _, m := range validMoves {
+		if m == position {
+			isValid = true
+			break
+		}
+	}
+	if !isValid {
+		return nil, errInvalidPosition
+	}
+
+	if err := g.OpponentMove(position); err != nil {
+		return nil, err
+	}
+
+	if !g.IsOver() && g.IsMenaceTurn() {
+		pos, err := g.MenaceMove()
+		if err == nil && pos >= 0 {
+			menaceMove = &pos
+		}
 	}
 
 	if g.IsOver() {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Game is already over"})
-		return
+		h.gameManager.FinishGame(g.ID)
 	}
 
-	if !g.IsOpponentTurn() {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "It's not your turn - waiting for MENACE"})
-		return
+	return menaceMove, nil
+}
+
+// applyHumanMove plays position for whichever side token holds in g, a
+// vs_human game, and finishes the game in gameManager if it's now over.
+// MENACE never moves itself in this mode, so the returned menaceMove is
+// always nil - it only exists so MakeMove can treat both modes uniformly.
+func (h *Handler) applyHumanMove(g *game.Game, token string, position int) (menaceMove *int, err error) {
+	player, err := g.PlayerForToken(token)
+	if err != nil {
+		return nil, errInvalidToken
 	}
 
-	var req MoveRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
+	if g.IsOver() {
+		return nil, errGameOver
+	}
+	if player != g.GetCurrentTurn() {
+		return nil, errNotYourTurn
 	}
 
-	// Check if move is valid
 	validMoves := g.GetValidMoves()
 	isValid := false
 	for _, m := range validMoves {
-		if m == req.Position {
+		if m == position {
 			isValid = true
 			break
 		}
 	}
 	if !isValid {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":       "Invalid move: position is not available",
-			"valid_moves": validMoves,
-		})
+		return nil, errInvalidPosition
+	}
+
+	if err := g.HumanMove(player, position); err != nil {
+		return nil, err
+	}
+
+	if g.IsOver() {
+		h.gameManager.FinishGame(g.ID)
+	}
+
+	return nil, nil
+}
+
+// MakeMove processes a move
+// POST /api/game/:id/move
+func (h *Handler) MakeMove(c *gin.Context) {
+	gameID := c.Param("id")
+
+	g := h.gameManager.GetGame(gameID)
+	if g == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Game not found: " + gameID})
 		return
 	}
 
-	// Make opponent's move
-	if err := g.OpponentMove(req.Position); err != nil {
+	var req MoveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
 	var menaceMove *int
-
-	// If game continues and it's MENACE's turn, let MENACE respond
-	if !g.IsOver() && g.IsMenaceTurn() {
-		pos, err := g.MenaceMove()
-		if err == nil && pos >= 0 {
-			menaceMove = &pos
-		}
+	var err error
+	if g.Mode == game.ModeVsHuman {
+		menaceMove, err = h.applyHumanMove(g, req.PlayerToken, req.Position)
+	} else {
+		menaceMove, err = h.applyOpponentMove(g, req.Position)
 	}
-
-	// If game ended, apply learning
-	if g.IsOver() {
-		h.gameManager.FinishGame(gameID)
+	if err != nil {
+		body := gin.H{"error": err.Error()}
+		if errors.Is(err, errInvalidPosition) {
+			body["valid_moves"] = g.GetValidMoves()
+		}
+		c.JSON(http.StatusBadRequest, body)
+		return
 	}
 
 	var currentTurn *string
 	if !g.IsOver() {
-		ct := getPlayerSymbol(g.CurrentTurn)
+		ct := getPlayerSymbol(g.GetCurrentTurn())
 		currentTurn = &ct
 	}
 
 	c.JSON(http.StatusOK, MoveResponse{
-		Board:        g.Board.State(),
+		Board:        g.GetBoard().State(),
 		CurrentTurn:  currentTurn,
 		Status:       getGameStatus(g),
 		ValidMoves:   g.GetValidMoves(),
@@ -323,13 +464,13 @@ func (h *Handler) GetGameState(c *gin.Context) {
 
 	var currentTurn *string
 	if !g.IsOver() {
-		ct := getPlayerSymbol(g.CurrentTurn)
+		ct := getPlayerSymbol(g.GetCurrentTurn())
 		currentTurn = &ct
 	}
 
 	c.JSON(http.StatusOK, GameStateResponse{
 		GameID:       g.ID,
-		Board:        g.Board.State(),
+		Board:        g.GetBoard().State(),
 		CurrentTurn:  currentTurn,
 		MenacePlayer: getPlayerSymbol(g.MenacePlayer),
 		Status:       getGameStatus(g),
@@ -337,7 +478,144 @@ func (h *Handler) GetGameState(c *gin.Context) {
 		IsGameOver:   g.IsOver(),
 		Result:       getResultType(g),
 		Winner:       getWinner(g),
-		MoveCount:    len(g.Moves),
+		MoveCount:    len(g.GetMoves()),
+	})
+}
+
+// DeleteGame removes a game session explicitly, instead of waiting for
+// the idle janitor to evict it.
+// DELETE /api/game/:id
+func (h *Handler) DeleteGame(c *gin.Context) {
+	gameID := c.Param("id")
+
+	if !h.gameManager.DeleteGame(gameID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Game not found: " + gameID})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"deleted": gameID})
+}
+
+// ============================================================================
+// Game Listing & Stats Endpoints
+// ============================================================================
+
+type GameListEntry struct {
+	GameID      string  `json:"game_id"`
+	CurrentTurn string  `json:"current_turn"`
+	MoveCount   int     `json:"move_count"`
+	Status      string  `json:"status"`
+	Result      *string `json:"result"`
+}
+
+type GameListResponse struct {
+	Games []GameListEntry `json:"games"`
+}
+
+// ListGames returns every game the manager knows about, active or
+// finished.
+// GET /api/game/list
+func (h *Handler) ListGames(c *gin.Context) {
+	games := h.gameManager.ListGames()
+
+	entries := make([]GameListEntry, len(games))
+	for i, g := range games {
+		entries[i] = GameListEntry{
+			GameID:      g.ID,
+			CurrentTurn: getPlayerSymbol(g.GetCurrentTurn()),
+			MoveCount:   len(g.GetMoves()),
+			Status:      getGameStatus(g),
+			Result:      getResultType(g),
+		}
+	}
+
+	c.JSON(http.StatusOK, GameListResponse{Games: entries})
+}
+
+type MoveHistoryEntry struct {
+	Player     string `json:"player"`
+	Position   int    `json:"position"`
+	BoardAfter string `json:"board_after"`
+}
+
+// DecisionEntry is one matchbox MENACE consulted during the game: the
+// normalized board state, the bead counts it saw, and the move it drew.
+type DecisionEntry struct {
+	BoardState    string      `json:"board_state"`
+	BeadsSnapshot map[int]int `json:"beads_snapshot"`
+	ChosenMove    int         `json:"chosen_move"`
+}
+
+// ReinforcementEntry is one bead-count change Learn applied once the game
+// ended.
+type ReinforcementEntry struct {
+	BoardState string      `json:"board_state"`
+	Position   int         `json:"position"`
+	Change     int         `json:"change"`
+	BeadsAfter map[int]int `json:"beads_after"`
+}
+
+type GameStatsResponse struct {
+	GameID        string               `json:"game_id"`
+	Moves         []MoveHistoryEntry   `json:"moves"`
+	Decisions     []DecisionEntry      `json:"decisions"`
+	Reinforcement []ReinforcementEntry `json:"reinforcement"`
+	IsGameOver    bool                 `json:"is_game_over"`
+	Result        *string              `json:"result"`
+}
+
+// GetGameStats returns MENACE's full decision and reinforcement trace for
+// one game: every move played, the matchbox/beads MENACE consulted for
+// each of its own moves, and the bead deltas Learn applied once the game
+// ended - enough to see exactly why MENACE played what it played.
+// GET /api/game/:id/stats
+func (h *Handler) GetGameStats(c *gin.Context) {
+	gameID := c.Param("id")
+
+	g := h.gameManager.GetGame(gameID)
+	if g == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Game not found: " + gameID})
+		return
+	}
+
+	gameMoves := g.GetMoves()
+	moves := make([]MoveHistoryEntry, len(gameMoves))
+	for i, m := range gameMoves {
+		moves[i] = MoveHistoryEntry{
+			Player:     string(m.Player),
+			Position:   m.Position,
+			BoardAfter: m.BoardAfter,
+		}
+	}
+
+	gameDecisions := g.GetDecisions()
+	decisions := make([]DecisionEntry, len(gameDecisions))
+	for i, d := range gameDecisions {
+		decisions[i] = DecisionEntry{
+			BoardState:    d.BoardState,
+			BeadsSnapshot: d.BeadsSnapshot,
+			ChosenMove:    d.ChosenMove,
+		}
+	}
+
+	gameReinforcement := g.GetReinforcement()
+	reinforcement := make([]ReinforcementEntry, len(gameReinforcement))
+	for i, d := range gameReinforcement {
+		reinforcement[i] = ReinforcementEntry{
+			BoardState: d.BoardState,
+			Position:   d.Position,
+			Change:     d.Change,
+			BeadsAfter: d.BeadsAfter,
+		}
+	}
+
+	c.JSON(http.StatusOK, GameStatsResponse{
+		GameID:        g.ID,
+		Moves:         moves,
+		Decisions:     decisions,
+		Reinforcement: reinforcement,
+		IsGameOver:    g.IsOver(),
+		Result:        getResultType(g),
 	})
 }
 
@@ -408,9 +686,26 @@ func (h *Handler) QueryMatchbox(c *gin.Context) {
 // ListMatchboxes returns all matchboxes
 // GET /api/menace/matchboxes
 func (h *Handler) ListMatchboxes(c *gin.Context) {
-	matchboxes := make([]map[string]interface{}, 0)
+	const defaultLimit = 100
+	const maxLimit = 765 // max possible matchboxes, see EstimateTraining
+
+	limit := defaultLimit
+	if v, err := strconv.Atoi(c.Query("limit")); err == nil && v > 0 {
+		limit = v
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+
+	offset := 0
+	if v, err := strconv.Atoi(c.Query("offset")); err == nil && v > 0 {
+		offset = v
+	}
+
+	snapshot := h.menace.MatchboxesSnapshot()
+	matchboxes := make([]map[string]interface{}, 0, len(snapshot))
 
-	for state, mb := range h.menace.Matchboxes {
+	for state, mb := range snapshot {
 		beadsStr := make(map[string]int)
 		var topMove *int
 		maxBeads := 0
@@ -432,9 +727,44 @@ func (h *Handler) ListMatchboxes(c *gin.Context) {
 		})
 	}
 
+	// Sort for stable pagination across calls - map iteration order isn't
+	// one. times_used/total_beads sort highest first, since that's what a
+	// UI paging through the most active matchboxes wants; board_state,
+	// the default, just needs to be consistent rather than meaningful.
+	switch c.Query("sort") {
+	case "times_used":
+		sort.Slice(matchboxes, func(i, j int) bool {
+			return matchboxes[i]["times_used"].(int) > matchboxes[j]["times_used"].(int)
+		})
+	case "total_beads":
+		sort.Slice(matchboxes, func(i, j int) bool {
+			return matchboxes[i]["total_beads"].(int) > matchboxes[j]["total_beads"].(int)
+		})
+	default:
+		sort.Slice(matchboxes, func(i, j int) bool {
+			return matchboxes[i]["board_state"].(string) < matchboxes[j]["board_state"].(string)
+		})
+	}
+
+	total := len(matchboxes)
+	if offset > total {
+		offset = total
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+
+	var nextOffset *int
+	if end < total {
+		n := end
+		nextOffset = &n
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"count":      len(matchboxes),
-		"matchboxes": matchboxes,
+		"count":       total,
+		"matchboxes":  matchboxes[offset:end],
+		"next_offset": nextOffset,
 	})
 }
 
@@ -471,7 +801,7 @@ func (h *Handler) GetMenaceHistory(c *gin.Context) {
 // POST /api/training/self-play
 func (h *Handler) SelfPlayTraining(c *gin.Context) {
 	var req TrainingRequest
-	req.NumGames = 100 // Default
+	req.NumGames = h.cfg.DefaultTrainingGames
 	req.Opponent = "random"
 
 	if err := c.ShouldBindJSON(&req); err != nil && c.Request.ContentLength > 0 {
@@ -479,14 +809,51 @@ func (h *Handler) SelfPlayTraining(c *gin.Context) {
 		return
 	}
 
+	// binding:"max=5000000" on TrainingRequest.NumGames is just a sanity
+	// ceiling against pathological request bodies - the real, configurable
+	// limit is cfg.MaxTrainingGames.
+	if req.NumGames > h.cfg.MaxTrainingGames {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("num_games %d exceeds the configured maximum of %d", req.NumGames, h.cfg.MaxTrainingGames),
+		})
+		return
+	}
+
+	if req.RewardWin != nil || req.RewardDraw != nil || req.RewardLoss != nil {
+		restore := h.menace.OverrideRewards(req.RewardWin, req.RewardDraw, req.RewardLoss)
+		defer restore()
+	}
+
 	startTime := time.Now()
 	initialMatchboxes := h.menace.GetMatchboxCount()
 
 	wins := 0
 	losses := 0
 	draws := 0
+	breakdown := make(map[string]*OpponentStats)
+
+	var self *selfOpponent
+	if req.Opponent == "self" {
+		self = newSelfOpponent()
+	}
 
 	for i := 0; i < req.NumGames; i++ {
+		var opp trainingOpponent
+		switch req.Opponent {
+		case "perfect":
+			opp = boardOpponent{opponent.PerfectPlayer{}}
+		case "heuristic":
+			opp = boardOpponent{opponent.HeuristicPlayer{}}
+		case "self":
+			opp = self
+		case "mixed":
+			opp = mixedOpponent()
+		case "curriculum":
+			opp = curriculumOpponent(h.menace.GamesPlayed)
+		default:
+			opp = boardOpponent{opponent.RandomPlayer{}}
+		}
+
 		// Alternate who goes first
 		menaceFirst := rand.Intn(2) == 0
 		g := h.gameManager.CreateGame(menaceFirst)
@@ -496,10 +863,8 @@ func (h *Handler) SelfPlayTraining(c *gin.Context) {
 			if g.IsMenaceTurn() {
 				g.MenaceMove()
 			} else {
-				// Bot's turn - random move
-				validMoves := g.GetValidMoves()
-				if len(validMoves) > 0 {
-					move := validMoves[rand.Intn(len(validMoves))]
+				move := opp.Move(g)
+				if move >= 0 {
 					g.OpponentMove(move)
 				}
 			}
@@ -507,16 +872,27 @@ func (h *Handler) SelfPlayTraining(c *gin.Context) {
 
 		// Apply learning
 		h.gameManager.FinishGame(g.ID)
-
-		// Track results
 		result := g.GetResult()
+		if learner, ok := opp.(opponentLearner); ok {
+			learner.Learn(invertForOpponent(result))
+		}
+
+		// Track results, overall and per-opponent-style
+		stats := breakdown[opp.Name()]
+		if stats == nil {
+			stats = &OpponentStats{}
+			breakdown[opp.Name()] = stats
+		}
 		switch result {
 		case board.ResultWin:
 			wins++
+			stats.Wins++
 		case board.ResultLoss:
 			losses++
+			stats.Losses++
 		case board.ResultDraw:
 			draws++
+			stats.Draws++
 		}
 	}
 
@@ -525,8 +901,8 @@ func (h *Handler) SelfPlayTraining(c *gin.Context) {
 	totalMatchboxes := h.menace.GetMatchboxCount()
 	gamesPerSecond := float64(req.NumGames) / elapsed
 	
-	// Estimate database size: ~200 bytes per matchbox
-	estimatedDBSizeKB := float64(totalMatchboxes*200) / 1024
+	// Estimate database size using the configured bytes-per-matchbox
+	estimatedDBSizeKB := float64(totalMatchboxes*h.cfg.BytesPerMatchbox) / 1024
 
 	c.JSON(http.StatusOK, TrainingResponse{
 		GamesPlayed:      req.NumGames,
@@ -538,6 +914,7 @@ func (h *Handler) SelfPlayTraining(c *gin.Context) {
 		GamesPerSecond:   gamesPerSecond,
 		TotalMatchboxes:  totalMatchboxes,
 		EstimatedDBSizeKB: estimatedDBSizeKB,
+		OpponentBreakdown: breakdown,
 	})
 }
 
@@ -577,17 +954,16 @@ func (h *Handler) EstimateTraining(c *gin.Context) {
 	currentMatchboxes := h.menace.GetMatchboxCount()
 	currentGames := h.menace.GamesPlayed
 
-	// Base estimate: ~1400 games per second (conservative)
-	gamesPerSecond := 1400.0
+	// Base estimate, from the configured games-per-second throughput
+	gamesPerSecond := h.cfg.GamesPerSecondEstimate
 	estimatedTime := float64(req.NumGames) / gamesPerSecond
 
 	// Storage estimation:
 	// - Max ~765 unique matchboxes possible
-	// - Each matchbox: ~200 bytes
-	// - Game history: ~50 bytes per game
+	// - Bytes per matchbox/game history come from config
 	maxMatchboxes := 765
-	bytesPerMatchbox := 200
-	bytesPerGameHistory := 50
+	bytesPerMatchbox := h.cfg.BytesPerMatchbox
+	bytesPerGameHistory := h.cfg.BytesPerGameHistory
 
 	projectedMatchboxes := currentMatchboxes + req.NumGames/10
 	if projectedMatchboxes > maxMatchboxes {
@@ -623,6 +999,68 @@ func (h *Handler) ResetMenace(c *gin.Context) {
 	})
 }
 
+// ============================================================================
+// Lobby Endpoints
+// ============================================================================
+
+type LobbyJoinRequest struct {
+	// Token identifies the caller across requests. Leave empty to have
+	// the server generate one; a parked caller re-POSTs with the same
+	// token to discover whether they've since been paired.
+	Token string `json:"token"`
+}
+
+type LobbyJoinResponse struct {
+	Status     string  `json:"status"` // "waiting" or "matched"
+	Token      string  `json:"token"`
+	GameID     *string `json:"game_id,omitempty"`
+	YourSymbol *string `json:"your_symbol,omitempty"`
+}
+
+// JoinLobby either pairs the caller with the waiting opponent into a
+// fresh vs_human game, or parks them as the waiting seat.
+// POST /api/lobby/join
+func (h *Handler) JoinLobby(c *gin.Context) {
+	var req LobbyJoinRequest
+	if err := c.ShouldBindJSON(&req); err != nil && c.Request.ContentLength > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Token == "" {
+		req.Token = uuid.New().String()
+	}
+
+	seat, matched := h.lobby.Join(req.Token)
+	if !matched {
+		c.JSON(http.StatusOK, LobbyJoinResponse{Status: "waiting", Token: req.Token})
+		return
+	}
+	if seat.Game == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "server is shutting down, not accepting new games"})
+		return
+	}
+
+	gameID := seat.Game.ID
+	symbol := getPlayerSymbol(seat.Symbol)
+	c.JSON(http.StatusOK, LobbyJoinResponse{
+		Status:     "matched",
+		Token:      req.Token,
+		GameID:     &gameID,
+		YourSymbol: &symbol,
+	})
+}
+
+type LobbyStatusResponse struct {
+	OpenSeats int `json:"open_seats"`
+}
+
+// GetLobby reports how many players are currently waiting for an
+// opponent.
+// GET /api/lobby
+func (h *Handler) GetLobby(c *gin.Context) {
+	c.JSON(http.StatusOK, LobbyStatusResponse{OpenSeats: h.lobby.OpenSeats()})
+}
+
 // ============================================================================
 // Health Check
 // ============================================================================
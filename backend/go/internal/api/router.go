@@ -6,26 +6,35 @@ package api
 import (
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/winzerprince/menace/backend/go/pkg/config"
 )
 
-// SetupRouter creates and configures the Gin router
-func SetupRouter(handler *Handler) *gin.Engine {
+// SetupRouter creates and configures the Gin router. cfg.AllowedOrigins
+// drives CORS so it can be tuned per-deployment without recompiling.
+func SetupRouter(handler *Handler, cfg config.Config) *gin.Engine {
 	router := gin.Default()
 
 	// Configure CORS to allow frontend requests
-	config := cors.DefaultConfig()
-	config.AllowOrigins = []string{"http://localhost:5173", "http://localhost:3000", "http://127.0.0.1:5173"}
-	config.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
-	config.AllowHeaders = []string{"Origin", "Content-Type", "Accept"}
-	router.Use(cors.New(config))
+	corsConfig := cors.DefaultConfig()
+	corsConfig.AllowOrigins = cfg.AllowedOrigins
+	corsConfig.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
+	corsConfig.AllowHeaders = []string{"Origin", "Content-Type", "Accept"}
+	router.Use(cors.New(corsConfig))
 
 	// API routes under /api prefix
 	api := router.Group("/api")
 	{
 		// Game endpoints
 		api.POST("/game/new", handler.NewGame)
+		api.GET("/game/list", handler.ListGames)
 		api.POST("/game/:id/move", handler.MakeMove)
 		api.GET("/game/:id", handler.GetGameState)
+		api.GET("/game/:id/stats", handler.GetGameStats)
+		api.DELETE("/game/:id", handler.DeleteGame)
+
+		// Lobby endpoints
+		api.POST("/lobby/join", handler.JoinLobby)
+		api.GET("/lobby", handler.GetLobby)
 
 		// MENACE endpoints
 		api.GET("/menace/stats", handler.GetMenaceStats)
@@ -38,6 +47,10 @@ func SetupRouter(handler *Handler) *gin.Engine {
 		api.POST("/training/self-play", handler.SelfPlayTraining)
 		api.POST("/training/estimate", handler.EstimateTraining)
 
+		// WebSocket streams
+		api.GET("/ws/game/:id", handler.WSGame)
+		api.GET("/ws/training", handler.WSTraining)
+
 		// Health check
 		api.GET("/health", handler.HealthCheck)
 	}
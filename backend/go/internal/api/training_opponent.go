@@ -0,0 +1,97 @@
+package api
+
+import (
+	"math/rand"
+
+	"github.com/winzerprince/menace/backend/go/pkg/board"
+	"github.com/winzerprince/menace/backend/go/pkg/game"
+	"github.com/winzerprince/menace/backend/go/pkg/menace"
+	"github.com/winzerprince/menace/backend/go/pkg/opponent"
+)
+
+// Game counts that gate curriculum mode's difficulty ramp.
+const (
+	curriculumHeuristicAt = 100
+	curriculumPerfectAt   = 500
+)
+
+// trainingOpponent picks the move SelfPlayTraining's non-MENACE side plays.
+// It's defined here rather than in pkg/opponent because an opponent that
+// needs a *game.Game would have to import pkg/game, which already imports
+// pkg/menace, which imports pkg/opponent for Trainer - a cycle. Keeping the
+// game-level interface at the consumer mirrors menace.MatchboxStore.
+type trainingOpponent interface {
+	Move(g *game.Game) int
+	Name() string
+}
+
+// opponentLearner is implemented by trainingOpponents that need to be told a
+// game's outcome, like selfOpponent's second Menace instance.
+type opponentLearner interface {
+	Learn(result board.GameResult)
+}
+
+// boardOpponent adapts a board-level opponent.Opponent to trainingOpponent.
+type boardOpponent struct {
+	opponent.Opponent
+}
+
+func (b boardOpponent) Move(g *game.Game) int {
+	return b.Opponent.Move(g.GetBoard(), g.MenacePlayer.Other())
+}
+
+// selfOpponent is a second, independently learning Menace instance:
+// "self-play" in the sense of two matchbox populations training against
+// each other, rather than one side using a fixed strategy.
+type selfOpponent struct {
+	menace *menace.Menace
+}
+
+func newSelfOpponent() *selfOpponent {
+	return &selfOpponent{menace: menace.NewMenace(board.PlayerO)}
+}
+
+func (s *selfOpponent) Move(g *game.Game) int { return s.menace.GetMove(g.GetBoard()) }
+func (s *selfOpponent) Name() string          { return "self" }
+func (s *selfOpponent) Learn(result board.GameResult) {
+	s.menace.Learn(result)
+}
+
+// mixedOpponent picks a fresh sub-opponent each game: 60% random, 30%
+// heuristic, 10% perfect.
+func mixedOpponent() trainingOpponent {
+	switch r := rand.Float64(); {
+	case r < 0.6:
+		return boardOpponent{opponent.RandomPlayer{}}
+	case r < 0.9:
+		return boardOpponent{opponent.HeuristicPlayer{}}
+	default:
+		return boardOpponent{opponent.PerfectPlayer{}}
+	}
+}
+
+// curriculumOpponent ramps difficulty as gamesPlayed grows: random early,
+// heuristic once MENACE has some experience, perfect once it has a lot.
+func curriculumOpponent(gamesPlayed int) trainingOpponent {
+	switch {
+	case gamesPlayed < curriculumHeuristicAt:
+		return boardOpponent{opponent.RandomPlayer{}}
+	case gamesPlayed < curriculumPerfectAt:
+		return boardOpponent{opponent.HeuristicPlayer{}}
+	default:
+		return boardOpponent{opponent.PerfectPlayer{}}
+	}
+}
+
+// invertForOpponent flips a MENACE-perspective result to the opponent's
+// perspective, for opponentLearner.Learn.
+func invertForOpponent(result board.GameResult) board.GameResult {
+	switch result {
+	case board.ResultWin:
+		return board.ResultLoss
+	case board.ResultLoss:
+		return board.ResultWin
+	default:
+		return result
+	}
+}
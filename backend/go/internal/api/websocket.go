@@ -0,0 +1,57 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/winzerprince/menace/backend/go/pkg/game"
+)
+
+// wsMoveMessage is the payload a client sends over /api/ws/game/:id to make
+// a move, mirroring MoveRequest's fields.
+type wsMoveMessage struct {
+	Position    int    `json:"position"`
+	PlayerToken string `json:"player_token"`
+}
+
+// WSGame upgrades the connection to a WebSocket streaming every event for
+// one game (moves, matchbox updates, learning), and accepts inbound
+// {"position": N} messages as moves - an alternative to POST
+// /api/game/:id/move for clients that want a persistent connection.
+// GET /api/ws/game/:id
+func (h *Handler) WSGame(c *gin.Context) {
+	gameID := c.Param("id")
+
+	g := h.gameManager.GetGame(gameID)
+	if g == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Game not found: " + gameID})
+		return
+	}
+
+	onMessage := func(raw []byte) {
+		var msg wsMoveMessage
+		if err := json.Unmarshal(raw, &msg); err != nil {
+			return
+		}
+		if g.Mode == game.ModeVsHuman {
+			h.applyHumanMove(g, msg.PlayerToken, msg.Position)
+		} else {
+			h.applyOpponentMove(g, msg.Position)
+		}
+	}
+
+	if err := h.hub.ServeWS(c.Writer, c.Request, gameID, onMessage); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
+
+// WSTraining upgrades the connection to a WebSocket streaming every event
+// across all games and training runs - the feed behind a live training
+// dashboard. It's a pure subscriber: inbound messages are ignored.
+// GET /api/ws/training
+func (h *Handler) WSTraining(c *gin.Context) {
+	if err := h.hub.ServeWS(c.Writer, c.Request, "", nil); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
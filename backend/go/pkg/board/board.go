@@ -3,11 +3,11 @@ Package board provides the tic-tac-toe board representation and game logic.
 
 DESIGN DECISIONS:
 
-1. Board Representation: String of 9 characters
+1. Board Representation: String of size*size characters
 
   - Each position is 'X', 'O', or '_' (empty)
 
-  - Positions are numbered 0-8:
+  - For the default 3x3 board, positions are numbered 0-8:
 
     0 | 1 | 2
     ---------
@@ -16,9 +16,17 @@ DESIGN DECISIONS:
     6 | 7 | 8
 
 2. State Normalization:
+
   - A board can be rotated 4 ways and flipped 2 ways = 8 equivalent states
+
   - We normalize to the "smallest" string representation
+
   - This reduces the number of unique states MENACE needs to learn
+
+    3. Rules: board size, win length, winning lines, and the symmetry group are
+    all supplied by a Rules implementation rather than hard-coded, so the same
+    Board/Matchbox/Normalize machinery can learn larger m-n-k games by
+    plugging in a different Rules - see NewWithRules.
 */
 package board
 
@@ -55,56 +63,168 @@ const (
 	ResultInProgress GameResult = "in_progress"
 )
 
-// WinningLines contains all possible winning combinations
-// Each slice represents 3 positions that form a winning line
-var WinningLines = [][]int{
-	{0, 1, 2}, // Top row
-	{3, 4, 5}, // Middle row
-	{6, 7, 8}, // Bottom row
-	{0, 3, 6}, // Left column
-	{1, 4, 7}, // Middle column
-	{2, 5, 8}, // Right column
-	{0, 4, 8}, // Diagonal top-left to bottom-right
-	{2, 4, 6}, // Diagonal top-right to bottom-left
+// Rules defines the geometry and win condition of a board, so Board,
+// WinningLines, and Normalize don't have to hard-code a 3x3 grid. A Rules
+// implementation must be square (Size() x Size()); win lines narrower than
+// Size() (WinLength() < Size()) give m-n-k games like Gomoku.
+type Rules interface {
+	// Size is the side length of the square grid.
+	Size() int
+	// WinLength is how many in a row wins.
+	WinLength() int
+	// WinningLines enumerates every run of WinLength positions that wins
+	// the game, as position indices into a Size()*Size() state string.
+	WinningLines() [][]int
+	// Symmetries enumerates the board's symmetry group as permutations:
+	// transform[newPos] = oldPos. Index 0 must be the identity.
+	Symmetries() [][]int
+	// EmptyState is the all-empty state string for this ruleset.
+	EmptyState() string
+}
+
+// TicTacToe3 is the classic 3x3, three-in-a-row ruleset, and the default
+// Rules used by New and NewEmpty.
+type TicTacToe3 struct{}
+
+func (TicTacToe3) Size() int             { return 3 }
+func (TicTacToe3) WinLength() int        { return 3 }
+func (TicTacToe3) WinningLines() [][]int { return GenerateLines(3, 3) }
+func (TicTacToe3) Symmetries() [][]int   { return SquareSymmetries(3, true) }
+func (TicTacToe3) EmptyState() string    { return strings.Repeat("_", 3*3) }
+
+// GenerateLines enumerates every run of winLength cells in a row, column, or
+// diagonal on a size*size grid, as position indices. For winLength == size
+// this reduces to the classic rows + columns + two diagonals; for
+// winLength < size it additionally produces every sliding window, which is
+// what an m-n-k game like Gomoku needs.
+func GenerateLines(size, winLength int) [][]int {
+	index := func(r, c int) int { return r*size + c }
+	var lines [][]int
+
+	for r := 0; r < size; r++ {
+		for cStart := 0; cStart+winLength <= size; cStart++ {
+			line := make([]int, winLength)
+			for i := 0; i < winLength; i++ {
+				line[i] = index(r, cStart+i)
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	for c := 0; c < size; c++ {
+		for rStart := 0; rStart+winLength <= size; rStart++ {
+			line := make([]int, winLength)
+			for i := 0; i < winLength; i++ {
+				line[i] = index(rStart+i, c)
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	for rStart := 0; rStart+winLength <= size; rStart++ {
+		for cStart := 0; cStart+winLength <= size; cStart++ {
+			line := make([]int, winLength)
+			for i := 0; i < winLength; i++ {
+				line[i] = index(rStart+i, cStart+i)
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	for rStart := 0; rStart+winLength <= size; rStart++ {
+		for cStart := winLength - 1; cStart < size; cStart++ {
+			line := make([]int, winLength)
+			for i := 0; i < winLength; i++ {
+				line[i] = index(rStart+i, cStart-i)
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	return lines
 }
 
-// Transformations contains mappings for board rotations and reflections
-// Each slice shows where each position maps to after transformation
-var Transformations = [][]int{
-	{0, 1, 2, 3, 4, 5, 6, 7, 8}, // Identity
-	{6, 3, 0, 7, 4, 1, 8, 5, 2}, // Rotate 90°
-	{8, 7, 6, 5, 4, 3, 2, 1, 0}, // Rotate 180°
-	{2, 5, 8, 1, 4, 7, 0, 3, 6}, // Rotate 270°
-	{2, 1, 0, 5, 4, 3, 8, 7, 6}, // Flip horizontal
-	{6, 7, 8, 3, 4, 5, 0, 1, 2}, // Flip vertical
-	{0, 3, 6, 1, 4, 7, 2, 5, 8}, // Flip diagonal
-	{8, 5, 2, 7, 4, 1, 6, 3, 0}, // Flip anti-diagonal
+// SquareSymmetries generates the dihedral symmetry group of a size*size grid
+// programmatically: 4 rotations, plus their 4 mirror-image reflections when
+// includeReflections is true. Each transform is returned as
+// transform[newPos] = oldPos, matching applyTransform's convention.
+func SquareSymmetries(size int, includeReflections bool) [][]int {
+	coords := func(pos int) (int, int) { return pos / size, pos % size }
+	index := func(r, c int) int { return r*size + c }
+
+	byCoord := []func(r, c int) (int, int){
+		func(r, c int) (int, int) { return r, c },                       // identity
+		func(r, c int) (int, int) { return size - 1 - c, r },            // rotate 90
+		func(r, c int) (int, int) { return size - 1 - r, size - 1 - c }, // rotate 180
+		func(r, c int) (int, int) { return c, size - 1 - r },            // rotate 270
+	}
+	if includeReflections {
+		byCoord = append(byCoord,
+			func(r, c int) (int, int) { return r, size - 1 - c },            // flip horizontal
+			func(r, c int) (int, int) { return size - 1 - r, c },            // flip vertical
+			func(r, c int) (int, int) { return c, r },                       // flip diagonal
+			func(r, c int) (int, int) { return size - 1 - c, size - 1 - r }, // flip anti-diagonal
+		)
+	}
+
+	transforms := make([][]int, len(byCoord))
+	for i, f := range byCoord {
+		t := make([]int, size*size)
+		for newPos := 0; newPos < size*size; newPos++ {
+			r, c := coords(newPos)
+			oldR, oldC := f(r, c)
+			t[newPos] = index(oldR, oldC)
+		}
+		transforms[i] = t
+	}
+	return transforms
 }
 
-// EmptyBoard is the initial empty board state
+// WinningLines contains the default (3x3) possible winning combinations.
+// Kept for backward compatibility with code written against the original
+// hard-coded 3x3 board; prefer a Board's own rules.WinningLines() when
+// working with a non-default Rules.
+var WinningLines = TicTacToe3{}.WinningLines()
+
+// EmptyBoard is the initial empty board state for the default 3x3 ruleset
 const EmptyBoard = "_________"
 
-// Board represents a tic-tac-toe board
+// Board represents a square board under a given set of Rules
 type Board struct {
 	state string
+	rules Rules
 }
 
-// New creates a new board with the given state
+// New creates a new 3x3 board with the given state
 func New(state string) (*Board, error) {
-	if len(state) != 9 {
-		return nil, errors.New("board state must be 9 characters")
+	return NewWithRules(TicTacToe3{}, state)
+}
+
+// NewWithRules creates a new board with the given state under rules. This
+// is the entry point for non-default games (larger grids, shorter win
+// lengths); everything else in this package and in pkg/menace works
+// unchanged because they only ever go through Board and its methods.
+func NewWithRules(rules Rules, state string) (*Board, error) {
+	size := rules.Size()
+	if len(state) != size*size {
+		return nil, errors.New("board state must be size*size characters")
 	}
 	for _, c := range state {
 		if c != 'X' && c != 'O' && c != '_' {
 			return nil, errors.New("board state can only contain 'X', 'O', or '_'")
 		}
 	}
-	return &Board{state: state}, nil
+	return &Board{state: state, rules: rules}, nil
 }
 
-// NewEmpty creates a new empty board
+// NewEmpty creates a new empty 3x3 board
 func NewEmpty() *Board {
-	return &Board{state: EmptyBoard}
+	return &Board{state: TicTacToe3{}.EmptyState(), rules: TicTacToe3{}}
+}
+
+// NewEmptyWithRules creates a new empty board under rules.
+func NewEmptyWithRules(rules Rules) *Board {
+	return &Board{state: rules.EmptyState(), rules: rules}
 }
 
 // State returns the board state as a string
@@ -112,6 +232,11 @@ func (b *Board) State() string {
 	return b.state
 }
 
+// Rules returns the ruleset this board was created with
+func (b *Board) Rules() Rules {
+	return b.rules
+}
+
 // GetSquare returns the player at a given position (or PlayerNone if empty)
 func (b *Board) GetSquare(position int) Player {
 	char := b.state[position]
@@ -139,8 +264,8 @@ func (b *Board) GetEmptyPositions() []int {
 // MakeMove creates a new board with the move applied
 // The original board is not modified (immutability)
 func (b *Board) MakeMove(position int, player Player) (*Board, error) {
-	if position < 0 || position > 8 {
-		return nil, errors.New("position must be 0-8")
+	if position < 0 || position >= len(b.state) {
+		return nil, errors.New("position is out of range")
 	}
 	if b.state[position] != '_' {
 		return nil, errors.New("position is already occupied")
@@ -150,15 +275,25 @@ func (b *Board) MakeMove(position int, player Player) (*Board, error) {
 	newState := []byte(b.state)
 	newState[position] = player[0]
 
-	return &Board{state: string(newState)}, nil
+	return &Board{state: string(newState), rules: b.rules}, nil
 }
 
 // CheckWinner returns the winning player, or PlayerNone if no winner yet
 func (b *Board) CheckWinner() Player {
-	for _, line := range WinningLines {
-		a, c, d := b.state[line[0]], b.state[line[1]], b.state[line[2]]
-		if a != '_' && a == c && c == d {
-			return Player(string(a))
+	for _, line := range b.rules.WinningLines() {
+		first := b.state[line[0]]
+		if first == '_' {
+			continue
+		}
+		won := true
+		for _, pos := range line[1:] {
+			if b.state[pos] != first {
+				won = false
+				break
+			}
+		}
+		if won {
+			return Player(string(first))
 		}
 	}
 	return PlayerNone
@@ -191,34 +326,25 @@ func (b *Board) GetResult(player Player) GameResult {
 
 // applyTransform applies a transformation to the board state
 func applyTransform(state string, transform []int) string {
-	result := make([]byte, 9)
+	result := make([]byte, len(transform))
 	for newPos, oldPos := range transform {
 		result[newPos] = state[oldPos]
 	}
 	return string(result)
 }
 
-// inverseTransform applies the inverse of a transformation to a position
-// This maps a position on the normalized board back to the original board
-func inverseTransform(position int, transformIdx int) int {
-	transform := Transformations[transformIdx]
-	for origPos, normPos := range transform {
-		if normPos == position {
-			return origPos
-		}
-	}
-	return position
-}
-
-// Normalize returns the normalized board state and the transformation index used
-// The normalized state is the lexicographically smallest among all rotations/reflections
+// Normalize returns the normalized board state and the transformation index
+// used, searching b.rules.Symmetries() for the lexicographically smallest
+// variant.
 func (b *Board) Normalize() (string, int) {
+	symmetries := b.rules.Symmetries()
+
 	variants := make([]struct {
 		state string
 		idx   int
-	}, len(Transformations))
+	}, len(symmetries))
 
-	for i, transform := range Transformations {
+	for i, transform := range symmetries {
 		variants[i] = struct {
 			state string
 			idx   int
@@ -236,7 +362,7 @@ func (b *Board) Normalize() (string, int) {
 	return variants[0].state, variants[0].idx
 }
 
-// NormalizeState is a convenience function to normalize a state string
+// NormalizeState is a convenience function to normalize a 3x3 state string
 func NormalizeState(state string) (string, int, error) {
 	board, err := New(state)
 	if err != nil {
@@ -246,17 +372,27 @@ func NormalizeState(state string) (string, int, error) {
 	return normalized, idx, nil
 }
 
-// TransformPosition converts a position from original board to normalized board
-func TransformPosition(position int, transformIdx int) int {
-	return Transformations[transformIdx][position]
+// TransformPosition converts a position on b's original board to b's
+// normalized board, using b.rules.Symmetries()[transformIdx] - the same
+// table Normalize() searched to produce transformIdx. Passing a
+// transformIdx obtained from a different ruleset's Normalize is a bug.
+func (b *Board) TransformPosition(position int, transformIdx int) int {
+	return b.rules.Symmetries()[transformIdx][position]
 }
 
-// InverseTransformPosition converts a position from normalized board to original board
-func InverseTransformPosition(position int, transformIdx int) int {
-	return inverseTransform(position, transformIdx)
+// InverseTransformPosition converts a position on b's normalized board
+// back to b's original board, inverting b.rules.Symmetries()[transformIdx].
+func (b *Board) InverseTransformPosition(position int, transformIdx int) int {
+	transform := b.rules.Symmetries()[transformIdx]
+	for origPos, normPos := range transform {
+		if normPos == position {
+			return origPos
+		}
+	}
+	return position
 }
 
-// String returns a visual representation of the board
+// String returns a visual representation of a 3x3 board
 func (b *Board) String() string {
 	return b.state[0:1] + " | " + b.state[1:2] + " | " + b.state[2:3] + "\n" +
 		"---------\n" +
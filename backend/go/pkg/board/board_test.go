@@ -0,0 +1,58 @@
+package board
+
+import "testing"
+
+// gomoku4 is a 4x4, three-in-a-row ruleset used only to exercise TransformPosition
+// and InverseTransformPosition against a non-default Rules, since the package only
+// ships TicTacToe3 otherwise.
+type gomoku4 struct{}
+
+func (gomoku4) Size() int             { return 4 }
+func (gomoku4) WinLength() int        { return 3 }
+func (gomoku4) WinningLines() [][]int { return GenerateLines(4, 3) }
+func (gomoku4) Symmetries() [][]int   { return SquareSymmetries(4, true) }
+func (gomoku4) EmptyState() string    { return "________________" }
+
+func TestTransformPositionRoundTrip(t *testing.T) {
+	cases := []struct {
+		name  string
+		rules Rules
+	}{
+		{"TicTacToe3", TicTacToe3{}},
+		{"gomoku4", gomoku4{}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			b := NewEmptyWithRules(tc.rules)
+			size := tc.rules.Size()
+
+			for transformIdx := range tc.rules.Symmetries() {
+				for pos := 0; pos < size*size; pos++ {
+					normPos := b.TransformPosition(pos, transformIdx)
+					if normPos < 0 || normPos >= size*size {
+						t.Fatalf("TransformPosition(%d, %d) = %d, out of range [0, %d)", pos, transformIdx, normPos, size*size)
+					}
+					back := b.InverseTransformPosition(normPos, transformIdx)
+					if back != pos {
+						t.Errorf("InverseTransformPosition(TransformPosition(%d, %d), %d) = %d, want %d", pos, transformIdx, transformIdx, back, pos)
+					}
+				}
+			}
+		})
+	}
+}
+
+// TestTransformPositionUsesOwnRules guards against a regression where
+// TransformPosition indexed into a table hard-coded to TicTacToe3's 9-cell
+// symmetry group regardless of the board's actual Rules, which panicked for
+// any board bigger than 3x3.
+func TestTransformPositionUsesOwnRules(t *testing.T) {
+	b := NewEmptyWithRules(gomoku4{})
+	_, transformIdx := b.Normalize()
+
+	pos := b.TransformPosition(10, transformIdx)
+	if pos < 0 || pos >= 16 {
+		t.Fatalf("TransformPosition(10, %d) on a 4x4 board = %d, want a position in [0, 16)", transformIdx, pos)
+	}
+}
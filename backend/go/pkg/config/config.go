@@ -0,0 +1,227 @@
+/*
+Package config loads MENACE's server and training parameters with layered
+precedence: built-in constants, overridden by an optional JSON config
+file, overridden by environment variables. A fourth layer - per-request
+overrides, like a custom reward schedule for a single training run - is
+applied on top of a loaded Config by the HTTP layer itself, since only it
+knows about request bodies; see internal/api's SelfPlayTraining.
+*/
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Duration wraps time.Duration so Config marshals it to/from a
+// human-readable JSON string like "30m" instead of raw nanoseconds.
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// Config holds every server and training parameter that used to be a
+// hard-coded constant scattered across internal/api, so they can be tuned
+// without recompiling.
+type Config struct {
+	Port           string   `json:"port"`
+	AllowedOrigins []string `json:"allowed_origins"`
+
+	DefaultTrainingGames int `json:"default_training_games"`
+	MaxTrainingGames     int `json:"max_training_games"`
+
+	GamesPerSecondEstimate float64 `json:"games_per_second_estimate"`
+	BytesPerMatchbox       int     `json:"bytes_per_matchbox"`
+	BytesPerGameHistory    int     `json:"bytes_per_game_history"`
+
+	RewardWin           int `json:"reward_win"`
+	RewardDraw          int `json:"reward_draw"`
+	RewardLoss          int `json:"reward_loss"`
+	MinBeads            int `json:"min_beads"`
+	InitialBeadsPerMove int `json:"initial_beads_per_move"`
+
+	// GameSessionTTL is how long an idle game session is kept before the
+	// session janitor reclaims it.
+	GameSessionTTL Duration `json:"game_session_ttl"`
+
+	// MatchboxStorePath is where cmd/server persists MENACE's matchboxes
+	// on graceful shutdown, as a JSONStore file (see pkg/store).
+	MatchboxStorePath string `json:"matchbox_store_path"`
+}
+
+// Defaults returns the built-in constants every Config starts from,
+// before a config file or environment variables are applied.
+func Defaults() Config {
+	return Config{
+		Port: "8000",
+		AllowedOrigins: []string{
+			"http://localhost:5173",
+			"http://localhost:3000",
+			"http://127.0.0.1:5173",
+		},
+		DefaultTrainingGames:   100,
+		MaxTrainingGames:       5000000,
+		GamesPerSecondEstimate: 1400.0,
+		BytesPerMatchbox:       200,
+		BytesPerGameHistory:    50,
+		RewardWin:              3,
+		RewardDraw:             1,
+		RewardLoss:             1,
+		MinBeads:               1,
+		InitialBeadsPerMove:    3,
+		GameSessionTTL:         Duration(30 * time.Minute),
+		MatchboxStorePath:      defaultMatchboxStorePath(),
+	}
+}
+
+// defaultMatchboxStorePath is where MatchboxStorePath points by default:
+// next to the config file itself, under the same ~/.config/menace
+// directory.
+func defaultMatchboxStorePath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "menace", "matchboxes.json")
+}
+
+// DefaultPath is where LoadConfig looks for a config file when path is "".
+func DefaultPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "menace", "config.json")
+}
+
+// LoadConfig builds a Config by layering, in increasing precedence: the
+// built-in Defaults, the JSON file at path (DefaultPath() when path is
+// ""), and environment variables. A missing config file isn't an error -
+// LoadConfig just falls back to Defaults plus any env overrides.
+func LoadConfig(path string) (Config, error) {
+	cfg := Defaults()
+
+	if path == "" {
+		path = DefaultPath()
+	}
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+			if err := json.Unmarshal(data, &cfg); err != nil {
+				return Config{}, err
+			}
+		case !os.IsNotExist(err):
+			return Config{}, err
+		}
+	}
+
+	applyEnv(&cfg)
+
+	return cfg, nil
+}
+
+// applyEnv overrides cfg fields from environment variables when set,
+// taking precedence over both Defaults and the config file. Port keeps
+// reading the bare PORT variable the server has always honored; the rest
+// are new surface, prefixed MENACE_ to avoid colliding with anything else
+// in the environment.
+func applyEnv(cfg *Config) {
+	if v := os.Getenv("PORT"); v != "" {
+		cfg.Port = v
+	}
+	if v := os.Getenv("MENACE_ALLOWED_ORIGINS"); v != "" {
+		cfg.AllowedOrigins = strings.Split(v, ",")
+	}
+	if v, ok := envInt("MENACE_DEFAULT_TRAINING_GAMES"); ok {
+		cfg.DefaultTrainingGames = v
+	}
+	if v, ok := envInt("MENACE_MAX_TRAINING_GAMES"); ok {
+		cfg.MaxTrainingGames = v
+	}
+	if v, ok := envFloat("MENACE_GAMES_PER_SECOND_ESTIMATE"); ok {
+		cfg.GamesPerSecondEstimate = v
+	}
+	if v, ok := envInt("MENACE_BYTES_PER_MATCHBOX"); ok {
+		cfg.BytesPerMatchbox = v
+	}
+	if v, ok := envInt("MENACE_BYTES_PER_GAME_HISTORY"); ok {
+		cfg.BytesPerGameHistory = v
+	}
+	if v, ok := envInt("MENACE_REWARD_WIN"); ok {
+		cfg.RewardWin = v
+	}
+	if v, ok := envInt("MENACE_REWARD_DRAW"); ok {
+		cfg.RewardDraw = v
+	}
+	if v, ok := envInt("MENACE_REWARD_LOSS"); ok {
+		cfg.RewardLoss = v
+	}
+	if v, ok := envInt("MENACE_MIN_BEADS"); ok {
+		cfg.MinBeads = v
+	}
+	if v, ok := envInt("MENACE_INITIAL_BEADS_PER_MOVE"); ok {
+		cfg.InitialBeadsPerMove = v
+	}
+	if v, ok := envDuration("MENACE_GAME_SESSION_TTL"); ok {
+		cfg.GameSessionTTL = Duration(v)
+	}
+	if v := os.Getenv("MENACE_MATCHBOX_STORE_PATH"); v != "" {
+		cfg.MatchboxStorePath = v
+	}
+}
+
+func envInt(key string) (int, bool) {
+	v := os.Getenv(key)
+	if v == "" {
+		return 0, false
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+func envFloat(key string) (float64, bool) {
+	v := os.Getenv(key)
+	if v == "" {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0, false
+	}
+	return f, true
+}
+
+func envDuration(key string) (time.Duration, bool) {
+	v := os.Getenv(key)
+	if v == "" {
+		return 0, false
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, false
+	}
+	return d, true
+}
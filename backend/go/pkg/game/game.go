@@ -4,12 +4,14 @@ Package game manages individual game sessions between a human and MENACE.
 package game
 
 import (
+	"fmt"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/winzerprince/menace/backend/go/pkg/board"
 	"github.com/winzerprince/menace/backend/go/pkg/menace"
+	"github.com/winzerprince/menace/backend/go/pkg/stream"
 )
 
 // GameState represents the current state of a game
@@ -21,6 +23,15 @@ const (
 	StateFinished           GameState = "finished"
 )
 
+// GameMode distinguishes a game played against MENACE from one played
+// between two humans that MENACE merely observes.
+type GameMode string
+
+const (
+	ModeVsMenace GameMode = "vs_menace"
+	ModeVsHuman  GameMode = "vs_human"
+)
+
 // Move records a single move in the game
 type Move struct {
 	Player     board.Player `json:"player"`
@@ -31,6 +42,16 @@ type Move struct {
 
 // Game represents a single game session
 type Game struct {
+	// mu guards every field below that MenaceMove/OpponentMove/HumanMove
+	// mutate after construction (Board, CurrentTurn, Moves, Decisions,
+	// Reinforcement, Result, LastActivity). A game's REST handler
+	// goroutine and its WebSocket goroutine (see WSGame) can both apply
+	// moves to the same *Game at once, so reading or writing any of them
+	// outside mu is a data race. ID/MenacePlayer/Mode/PlayerX/PlayerO/
+	// CreatedAt are set once here and never reassigned, so they're safe
+	// to read without it.
+	mu sync.RWMutex
+
 	ID           string           `json:"id"`
 	Board        *board.Board     `json:"-"`
 	Menace       *menace.Menace   `json:"-"`
@@ -39,6 +60,42 @@ type Game struct {
 	Moves        []Move           `json:"moves"`
 	Result       board.GameResult `json:"result"`
 	CreatedAt    time.Time        `json:"created_at"`
+
+	// LastActivity is when this game last had a move applied, or
+	// CreatedAt if it hasn't had one yet. GameManager's janitor evicts
+	// games idle longer than its configured TTL.
+	LastActivity time.Time `json:"last_activity"`
+
+	// Mode is vs_menace for the usual human-vs-MENACE flow, or vs_human
+	// for a two-human game MENACE only observes. MenacePlayer still names
+	// the symbol MENACE learns from in either mode - it just never moves
+	// itself in vs_human.
+	Mode GameMode `json:"mode"`
+
+	// PlayerX and PlayerO are the session tokens allowed to move as each
+	// symbol in a vs_human game. Empty for vs_menace games, where the
+	// opponent isn't tied to a token. Never serialized - they're bearer
+	// credentials, not game state.
+	PlayerX string `json:"-"`
+	PlayerO string `json:"-"`
+
+	// Decisions is MENACE's decision trace for this game: one entry per
+	// MenaceMove call, recording the matchbox it consulted, the bead
+	// counts it saw, and the move it chose.
+	Decisions []menace.Decision `json:"decisions"`
+
+	// Reinforcement is the BeadDelta MENACE's Learn call applied at the
+	// end of this game, once it's over. Empty until then.
+	Reinforcement []menace.BeadDelta `json:"reinforcement"`
+
+	// finished is set by MarkFinished the first time it succeeds for this
+	// game, so two near-simultaneous move requests that both observe the
+	// game ending can't both trigger GameManager.FinishGame's Learn call.
+	finished bool
+
+	// bus, set by GameManager, receives move_made events. Nil means no
+	// telemetry is published.
+	bus stream.EventBus
 }
 
 // NewGame creates a new game session
@@ -48,24 +105,69 @@ func NewGame(m *menace.Menace, menacePlaysFirst bool) *Game {
 		menacePlayer = board.PlayerO
 	}
 
+	now := time.Now()
 	game := &Game{
 		ID:           uuid.New().String(),
 		Board:        board.NewEmpty(),
 		Menace:       m,
 		MenacePlayer: menacePlayer,
+		Mode:         ModeVsMenace,
+		CurrentTurn:  board.PlayerX, // X always goes first
+		Moves:        make([]Move, 0),
+		Result:       board.ResultInProgress,
+		CreatedAt:    now,
+		LastActivity: now,
+	}
+
+	m.StartNewGame()
+	return game
+}
+
+// NewHumanGame creates a vs_human game session between tokenX and tokenO.
+// MENACE doesn't move in this mode - it silently observes whichever side
+// matches menaceObserves via HumanMove and reinforces at game end exactly
+// as it would a game it played itself.
+func NewHumanGame(m *menace.Menace, menaceObserves board.Player, tokenX, tokenO string) *Game {
+	now := time.Now()
+	game := &Game{
+		ID:           uuid.New().String(),
+		Board:        board.NewEmpty(),
+		Menace:       m,
+		MenacePlayer: menaceObserves,
+		Mode:         ModeVsHuman,
 		CurrentTurn:  board.PlayerX, // X always goes first
 		Moves:        make([]Move, 0),
 		Result:       board.ResultInProgress,
-		CreatedAt:    time.Now(),
+		CreatedAt:    now,
+		LastActivity: now,
+		PlayerX:      tokenX,
+		PlayerO:      tokenO,
 	}
 
 	m.StartNewGame()
 	return game
 }
 
+// PlayerForToken maps a session token to the symbol it's allowed to move
+// as in this vs_human game. It returns an error if token doesn't match
+// either seat.
+func (g *Game) PlayerForToken(token string) (board.Player, error) {
+	switch token {
+	case g.PlayerX:
+		return board.PlayerX, nil
+	case g.PlayerO:
+		return board.PlayerO, nil
+	default:
+		return "", fmt.Errorf("token does not hold a seat in game %s", g.ID)
+	}
+}
+
 // State returns the current game state
 func (g *Game) State() GameState {
-	if g.IsOver() {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if g.isOverLocked() {
 		return StateFinished
 	}
 	if g.CurrentTurn == g.MenacePlayer {
@@ -76,48 +178,131 @@ func (g *Game) State() GameState {
 
 // IsOver returns true if the game has ended
 func (g *Game) IsOver() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.isOverLocked()
+}
+
+// isOverLocked is IsOver for callers already holding g.mu.
+func (g *Game) isOverLocked() bool {
 	return g.Board.IsGameOver()
 }
 
 // IsMenaceTurn returns true if it's MENACE's turn
 func (g *Game) IsMenaceTurn() bool {
-	return g.CurrentTurn == g.MenacePlayer && !g.IsOver()
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.isMenaceTurnLocked()
+}
+
+// isMenaceTurnLocked is IsMenaceTurn for callers already holding g.mu.
+func (g *Game) isMenaceTurnLocked() bool {
+	return g.CurrentTurn == g.MenacePlayer && !g.isOverLocked()
 }
 
 // IsOpponentTurn returns true if it's the opponent's turn
 func (g *Game) IsOpponentTurn() bool {
-	return g.CurrentTurn != g.MenacePlayer && !g.IsOver()
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.isOpponentTurnLocked()
+}
+
+// isOpponentTurnLocked is IsOpponentTurn for callers already holding g.mu.
+func (g *Game) isOpponentTurnLocked() bool {
+	return g.CurrentTurn != g.MenacePlayer && !g.isOverLocked()
 }
 
 // GetResult returns the game result from MENACE's perspective
 func (g *Game) GetResult() board.GameResult {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.getResultLocked()
+}
+
+// getResultLocked is GetResult for callers already holding g.mu.
+func (g *Game) getResultLocked() board.GameResult {
 	return g.Board.GetResult(g.MenacePlayer)
 }
 
 // GetValidMoves returns available positions
 func (g *Game) GetValidMoves() []int {
-	if g.IsOver() {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if g.isOverLocked() {
 		return []int{}
 	}
 	return g.Board.GetEmptyPositions()
 }
 
+// GetBoard returns the game's current board. Like the other Get-prefixed
+// accessors below, it takes g.mu so it's safe to call while a
+// MenaceMove/OpponentMove/HumanMove call is in flight on another
+// goroutine.
+func (g *Game) GetBoard() *board.Board {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.Board
+}
+
+// GetCurrentTurn returns whose turn it currently is.
+func (g *Game) GetCurrentTurn() board.Player {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.CurrentTurn
+}
+
+// GetMoves returns the moves played so far.
+func (g *Game) GetMoves() []Move {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.Moves
+}
+
+// GetDecisions returns MENACE's decision trace so far.
+func (g *Game) GetDecisions() []menace.Decision {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.Decisions
+}
+
+// GetReinforcement returns the bead deltas Learn applied at game end, or
+// nil if the game isn't over yet.
+func (g *Game) GetReinforcement() []menace.BeadDelta {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.Reinforcement
+}
+
+// GetLastActivity returns when this game last had a move applied, or
+// CreatedAt if it hasn't had one yet.
+func (g *Game) GetLastActivity() time.Time {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.LastActivity
+}
+
 // MenaceMove lets MENACE make its move
 func (g *Game) MenaceMove() (int, error) {
-	if g.IsOver() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.isOverLocked() {
 		return -1, nil
 	}
-	if !g.IsMenaceTurn() {
+	if !g.isMenaceTurnLocked() {
 		return -1, nil
 	}
 
-	position := g.Menace.GetMove(g.Board)
+	position, decision := g.Menace.GetMoveWithDecision(g.Board)
 
 	newBoard, err := g.Board.MakeMove(position, g.MenacePlayer)
 	if err != nil {
 		return -1, err
 	}
 
+	g.Decisions = append(g.Decisions, decision)
+
 	g.Board = newBoard
 	g.Moves = append(g.Moves, Move{
 		Player:     g.MenacePlayer,
@@ -127,20 +312,26 @@ func (g *Game) MenaceMove() (int, error) {
 	})
 
 	g.CurrentTurn = g.CurrentTurn.Other()
+	g.LastActivity = time.Now()
 
-	if g.IsOver() {
-		g.Result = g.GetResult()
+	if g.isOverLocked() {
+		g.Result = g.getResultLocked()
 	}
 
+	g.publishMove(g.MenacePlayer, position)
+
 	return position, nil
 }
 
 // OpponentMove processes the opponent's move
 func (g *Game) OpponentMove(position int) error {
-	if g.IsOver() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.isOverLocked() {
 		return nil
 	}
-	if !g.IsOpponentTurn() {
+	if !g.isOpponentTurnLocked() {
 		return nil
 	}
 
@@ -159,40 +350,181 @@ func (g *Game) OpponentMove(position int) error {
 	})
 
 	g.CurrentTurn = g.CurrentTurn.Other()
+	g.LastActivity = time.Now()
 
-	if g.IsOver() {
-		g.Result = g.GetResult()
+	if g.isOverLocked() {
+		g.Result = g.getResultLocked()
 	}
 
+	g.publishMove(opponentPlayer, position)
+
 	return nil
 }
 
+// HumanMove applies a move from player in a vs_human game. If player
+// matches MenacePlayer, MENACE observes the move before it's applied, so
+// it learns from this side of the game exactly as it would a game it
+// played itself.
+func (g *Game) HumanMove(player board.Player, position int) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.isOverLocked() {
+		return nil
+	}
+	if player != g.CurrentTurn {
+		return nil
+	}
+
+	if player == g.MenacePlayer {
+		g.Menace.ObserveMove(g.Board, position)
+	}
+
+	newBoard, err := g.Board.MakeMove(position, player)
+	if err != nil {
+		return err
+	}
+
+	g.Board = newBoard
+	g.Moves = append(g.Moves, Move{
+		Player:     player,
+		Position:   position,
+		BoardAfter: g.Board.State(),
+		Timestamp:  time.Now(),
+	})
+
+	g.CurrentTurn = g.CurrentTurn.Other()
+	g.LastActivity = time.Now()
+
+	if g.isOverLocked() {
+		g.Result = g.getResultLocked()
+	}
+
+	g.publishMove(player, position)
+
+	return nil
+}
+
+// MarkFinished reports whether this call is the first to observe g over
+// and not yet finished, atomically flipping g.finished if so. Two
+// near-simultaneous calls (e.g. the REST and WebSocket move paths both
+// reacting to the move that ends the game) only ever get true from one of
+// them, which is what lets GameManager.FinishGame call Learn exactly once
+// per game.
+func (g *Game) MarkFinished() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !g.isOverLocked() || g.finished {
+		return false
+	}
+	g.finished = true
+	return true
+}
+
+// recordReinforcement stores the BeadDelta Learn applied for this game, so
+// GET /api/game/:id/stats can show exactly which beads were added or
+// removed and to which matchboxes.
+func (g *Game) recordReinforcement(deltas []menace.BeadDelta) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.Reinforcement = deltas
+}
+
+// publishMove emits a move_made event, if this game has a bus attached.
+func (g *Game) publishMove(player board.Player, position int) {
+	if g.bus == nil {
+		return
+	}
+	g.bus.Publish(stream.Event{
+		Type:   stream.EventMoveMade,
+		GameID: g.ID,
+		Payload: stream.MovePayload{
+			Player:     string(player),
+			Position:   position,
+			BoardAfter: g.Board.State(),
+		},
+	})
+}
+
 // GameManager manages active game sessions
 type GameManager struct {
-	mu     sync.RWMutex
-	games  map[string]*Game
-	menace *menace.Menace
+	mu        sync.RWMutex
+	games     map[string]*Game
+	menace    *menace.Menace
+	bus       stream.EventBus
+	accepting bool
 }
 
 // NewGameManager creates a new game manager
 func NewGameManager(m *menace.Menace) *GameManager {
 	return &GameManager{
-		games:  make(map[string]*Game),
-		menace: m,
+		games:     make(map[string]*Game),
+		menace:    m,
+		accepting: true,
 	}
 }
 
-// CreateGame creates a new game
+// AttachEventBus wires bus into the manager so every game created from now
+// on publishes move_made events through it. Pass nil to detach.
+func (gm *GameManager) AttachEventBus(bus stream.EventBus) {
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+	gm.bus = bus
+}
+
+// CreateGame creates a new game, or returns nil if the manager has
+// stopped accepting new games (see StopAccepting).
 func (gm *GameManager) CreateGame(menacePlaysFirst bool) *Game {
+	gm.mu.Lock()
+	if !gm.accepting {
+		gm.mu.Unlock()
+		return nil
+	}
+	gm.mu.Unlock()
+
 	game := NewGame(gm.menace, menacePlaysFirst)
 
 	gm.mu.Lock()
+	game.bus = gm.bus
+	gm.games[game.ID] = game
+	gm.mu.Unlock()
+
+	return game
+}
+
+// CreateHumanGame creates a new vs_human game between tokenX and tokenO,
+// or returns nil if the manager has stopped accepting new games (see
+// StopAccepting). menaceObserves is the symbol MENACE silently learns
+// from.
+func (gm *GameManager) CreateHumanGame(menaceObserves board.Player, tokenX, tokenO string) *Game {
+	gm.mu.Lock()
+	if !gm.accepting {
+		gm.mu.Unlock()
+		return nil
+	}
+	gm.mu.Unlock()
+
+	game := NewHumanGame(gm.menace, menaceObserves, tokenX, tokenO)
+
+	gm.mu.Lock()
+	game.bus = gm.bus
 	gm.games[game.ID] = game
 	gm.mu.Unlock()
 
 	return game
 }
 
+// StopAccepting makes every subsequent CreateGame/CreateHumanGame call
+// return nil, without affecting games already in progress. Used during
+// graceful shutdown so no new game starts while the server waits for
+// active games to finish.
+func (gm *GameManager) StopAccepting() {
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+	gm.accepting = false
+}
+
 // GetGame retrieves a game by ID
 func (gm *GameManager) GetGame(id string) *Game {
 	gm.mu.RLock()
@@ -200,19 +532,105 @@ func (gm *GameManager) GetGame(id string) *Game {
 	return gm.games[id]
 }
 
+// DeleteGame removes a game by ID, reporting whether it existed. Backs
+// DELETE /api/game/:id for explicit cleanup of a game a client no longer
+// needs.
+func (gm *GameManager) DeleteGame(id string) bool {
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+
+	if _, exists := gm.games[id]; !exists {
+		return false
+	}
+	delete(gm.games, id)
+	return true
+}
+
+// EvictIdle removes every game whose LastActivity is older than ttl, and
+// returns how many it removed. Called periodically by StartJanitor.
+func (gm *GameManager) EvictIdle(ttl time.Duration) int {
+	cutoff := time.Now().Add(-ttl)
+
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+
+	evicted := 0
+	for id, g := range gm.games {
+		if g.GetLastActivity().Before(cutoff) {
+			delete(gm.games, id)
+			evicted++
+		}
+	}
+	return evicted
+}
+
+// WaitForActiveGames polls until no games are active or timeout elapses,
+// returning true if every game finished in time. Used during graceful
+// shutdown, after StopAccepting.
+func (gm *GameManager) WaitForActiveGames(timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if len(gm.GetActiveGames()) == 0 {
+			return true
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return len(gm.GetActiveGames()) == 0
+}
+
+// StartJanitor runs EvictIdle(ttl) every interval in a background
+// goroutine until the returned stop func is called.
+func (gm *GameManager) StartJanitor(ttl, interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				gm.EvictIdle(ttl)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(done) })
+	}
+}
+
 // FinishGame applies learning and optionally removes the game
 func (gm *GameManager) FinishGame(id string) {
 	gm.mu.Lock()
 	game, exists := gm.games[id]
 	gm.mu.Unlock()
 
-	if !exists || !game.IsOver() {
+	if !exists || !game.MarkFinished() {
 		return
 	}
 
 	// Apply learning
 	result := game.GetResult()
-	gm.menace.Learn(result)
+	deltas := gm.menace.Learn(result)
+	game.recordReinforcement(deltas)
+}
+
+// ListGames returns every game the manager knows about, active or
+// finished, in no particular order - the backing data for GET
+// /api/game/list.
+func (gm *GameManager) ListGames() []*Game {
+	gm.mu.RLock()
+	defer gm.mu.RUnlock()
+
+	games := make([]*Game, 0, len(gm.games))
+	for _, g := range gm.games {
+		games = append(games, g)
+	}
+	return games
 }
 
 // GetActiveGames returns all active game IDs
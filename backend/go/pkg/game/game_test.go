@@ -0,0 +1,86 @@
+package game
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/winzerprince/menace/backend/go/pkg/board"
+	"github.com/winzerprince/menace/backend/go/pkg/menace"
+)
+
+// TestConcurrentMovesDoNotRace guards against the regression where Game had
+// no mutex: a REST request goroutine and a WebSocket goroutine (see
+// WSGame's onMessage) could call MenaceMove/OpponentMove and the Get*
+// accessors on the same *Game at once, racing on Board/Moves/CurrentTurn/
+// Decisions. Run with -race to catch it.
+func TestConcurrentMovesDoNotRace(t *testing.T) {
+	m := menace.NewMenace(board.PlayerX)
+	g := NewGame(m, true)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for !g.IsOver() {
+				if g.IsMenaceTurn() {
+					g.MenaceMove()
+				} else {
+					valid := g.GetValidMoves()
+					if len(valid) == 0 {
+						break
+					}
+					g.OpponentMove(valid[0])
+				}
+				_ = g.GetBoard()
+				_ = g.GetCurrentTurn()
+				_ = g.GetMoves()
+				_ = g.GetDecisions()
+				_ = g.GetLastActivity()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if !g.IsOver() {
+		t.Fatalf("game did not finish: board %q", g.GetBoard().State())
+	}
+}
+
+// TestMarkFinishedOnlyOnce guards against the regression where
+// GameManager.FinishGame gated only on game.IsOver(): two near-simultaneous
+// move requests that both observe the game ending could both pass that
+// check and call Learn twice for one result. Only one of many concurrent
+// MarkFinished calls on an already-over game should return true.
+func TestMarkFinishedOnlyOnce(t *testing.T) {
+	m := menace.NewMenace(board.PlayerX)
+	g := NewGame(m, true)
+	for !g.IsOver() {
+		if g.IsMenaceTurn() {
+			g.MenaceMove()
+		} else {
+			g.OpponentMove(g.GetValidMoves()[0])
+		}
+	}
+
+	var wg sync.WaitGroup
+	results := make([]bool, 20)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = g.MarkFinished()
+		}(i)
+	}
+	wg.Wait()
+
+	winners := 0
+	for _, won := range results {
+		if won {
+			winners++
+		}
+	}
+	if winners != 1 {
+		t.Errorf("MarkFinished returned true %d times, want exactly 1", winners)
+	}
+}
@@ -0,0 +1,142 @@
+package game
+
+import (
+	"sync"
+	"time"
+
+	"github.com/winzerprince/menace/backend/go/pkg/board"
+)
+
+// Seat is what Lobby.Join hands back to a caller: the vs_human game they
+// were placed into and which symbol they're playing.
+type Seat struct {
+	Game   *Game
+	Symbol board.Player
+}
+
+// Lobby pairs incoming human players into vs_human games: the first
+// caller to Join is parked as the waiting seat, and the next caller is
+// paired with them into a fresh Game through manager, both seats
+// released once matched. Modeled on a red/blue seat-assignment
+// matchmaker - slot players into an open session before starting a new
+// one.
+type Lobby struct {
+	mu      sync.Mutex
+	manager *GameManager
+	waiting *waitingSeat
+	paired  map[string]pairedSeat
+}
+
+type waitingSeat struct {
+	token string
+}
+
+// pairedSeat is a Seat plus when it was claimed, so EvictStale can find
+// seats nobody has come back to collect (e.g. a player closed their tab
+// right after being matched).
+type pairedSeat struct {
+	seat      Seat
+	claimedAt time.Time
+}
+
+// NewLobby creates an empty Lobby backing games through manager.
+func NewLobby(manager *GameManager) *Lobby {
+	return &Lobby{
+		manager: manager,
+		paired:  make(map[string]pairedSeat),
+	}
+}
+
+// Join either pairs token with the waiting opponent into a fresh Game, or
+// parks token as the waiting seat if the lobby is empty. token identifies
+// the caller across requests - it becomes that player's PlayerX/PlayerO
+// value once paired. A parked caller discovers they've since been paired
+// by calling Join again with the same token, and Join stays idempotent
+// for as many further calls with that token as a retry or a second
+// concurrent request happens to make - it keeps returning the same seat
+// rather than falling through and re-parking token as the new waiting
+// seat, which would strand whoever was actually waiting next.
+func (l *Lobby) Join(token string) (Seat, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if p, ok := l.paired[token]; ok {
+		return p.seat, true
+	}
+
+	if l.waiting == nil || l.waiting.token == token {
+		l.waiting = &waitingSeat{token: token}
+		return Seat{}, false
+	}
+
+	opponentToken := l.waiting.token
+	l.waiting = nil
+
+	g := l.manager.CreateHumanGame(board.PlayerX, opponentToken, token)
+	now := time.Now()
+
+	l.paired[opponentToken] = pairedSeat{seat: Seat{Game: g, Symbol: board.PlayerX}, claimedAt: now}
+	seat := Seat{Game: g, Symbol: board.PlayerO}
+	l.paired[token] = pairedSeat{seat: seat, claimedAt: now}
+	return seat, true
+}
+
+// OpenSeats reports how many players are currently waiting for an
+// opponent - 0 or 1, since Join pairs them off as soon as a second
+// caller arrives.
+func (l *Lobby) OpenSeats() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.waiting == nil {
+		return 0
+	}
+	return 1
+}
+
+// EvictStale removes every paired seat nobody has collected within ttl of
+// being matched, and returns how many it removed. Without this, a player
+// who is matched and then never calls Join again (closed tab, crashed
+// client) would leave their seat in l.paired for the life of the process -
+// Join itself can no longer delete it on read now that repeat calls must
+// stay idempotent. Called periodically by StartJanitor.
+func (l *Lobby) EvictStale(ttl time.Duration) int {
+	cutoff := time.Now().Add(-ttl)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	evicted := 0
+	for token, p := range l.paired {
+		if p.claimedAt.Before(cutoff) {
+			delete(l.paired, token)
+			evicted++
+		}
+	}
+	return evicted
+}
+
+// StartJanitor runs EvictStale(ttl) every interval in a background
+// goroutine until the returned stop func is called.
+func (l *Lobby) StartJanitor(ttl, interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				l.EvictStale(ttl)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(done) })
+	}
+}
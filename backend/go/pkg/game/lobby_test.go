@@ -0,0 +1,73 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/winzerprince/menace/backend/go/pkg/board"
+	"github.com/winzerprince/menace/backend/go/pkg/menace"
+)
+
+// TestLobbyJoinIdempotentAfterMatch guards against the regression where a
+// matched token's second Join call fell through to l.waiting instead of
+// returning its seat again - since paired was deleted on first read, a
+// retried or duplicate concurrent request from the just-matched player
+// would strand whoever called Join next.
+func TestLobbyJoinIdempotentAfterMatch(t *testing.T) {
+	gm := NewGameManager(menace.NewMenace(board.PlayerX))
+	lobby := NewLobby(gm)
+
+	if _, matched := lobby.Join("alice"); matched {
+		t.Fatalf("alice should be parked waiting, not matched")
+	}
+
+	seat, matched := lobby.Join("bob")
+	if !matched {
+		t.Fatalf("bob should be matched against the waiting alice")
+	}
+
+	aliceSeat, matched := lobby.Join("alice")
+	if !matched {
+		t.Fatalf("alice should discover her match on re-Join")
+	}
+	if aliceSeat.Game != seat.Game {
+		t.Fatalf("alice and bob should share the same game")
+	}
+
+	// A retried/duplicate call from bob must return his same seat again,
+	// not re-park him as the new waiting opponent.
+	bobSeat, matched := lobby.Join("bob")
+	if !matched {
+		t.Fatalf("bob's duplicate Join should still report matched")
+	}
+	if bobSeat.Game != seat.Game || bobSeat.Symbol != seat.Symbol {
+		t.Fatalf("bob's duplicate Join returned a different seat: got %+v, want %+v", bobSeat, seat)
+	}
+
+	if open := lobby.OpenSeats(); open != 0 {
+		t.Fatalf("OpenSeats = %d, want 0 - bob must not have been re-parked", open)
+	}
+}
+
+// TestLobbyEvictStale guards against the regression this fix would
+// otherwise reintroduce: since Join no longer deletes a paired seat on
+// read, a token that's matched and never collects its seat must still be
+// cleaned up eventually instead of sitting in l.paired forever.
+func TestLobbyEvictStale(t *testing.T) {
+	gm := NewGameManager(menace.NewMenace(board.PlayerX))
+	lobby := NewLobby(gm)
+
+	lobby.Join("alice")
+	lobby.Join("bob") // pairs alice and bob
+
+	time.Sleep(5 * time.Millisecond)
+
+	if evicted := lobby.EvictStale(time.Millisecond); evicted != 2 {
+		t.Fatalf("EvictStale evicted %d, want 2 (alice and bob's paired seats)", evicted)
+	}
+
+	// alice's paired seat is gone, and nobody's waiting, so she re-parks.
+	if _, matched := lobby.Join("alice"); matched {
+		t.Fatalf("alice's stale seat should have been evicted, not still matched")
+	}
+}
@@ -0,0 +1,83 @@
+package menace
+
+import (
+	"strconv"
+
+	"github.com/winzerprince/menace/backend/go/pkg/board"
+	"github.com/winzerprince/menace/backend/go/pkg/stream"
+)
+
+// BeadDelta records one position's bead-count change from a single Learn
+// call, plus the matchbox's beads afterward, so a matchbox_updated event
+// can be published without re-reading the matchbox under lock, and so
+// callers like game.Game can record what reinforcement a game actually
+// applied.
+type BeadDelta struct {
+	BoardState string
+	Position   int
+	Change     int
+	BeadsAfter map[int]int
+}
+
+// AttachEventBus wires bus into m so GetOrCreateMatchbox and Learn publish
+// move/matchbox/learning telemetry through it. Pass nil to detach.
+func (m *Menace) AttachEventBus(bus stream.EventBus) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.bus = bus
+}
+
+// publishLearnEvents emits the matchbox_updated, learning_applied, and
+// (when one was just recorded) history_snapshot events for a completed
+// Learn call. It's a no-op if no bus is attached.
+func (m *Menace) publishLearnEvents(result board.GameResult, deltas []BeadDelta, snapshot HistorySnapshot, snapshotTaken bool) {
+	m.mu.RLock()
+	bus := m.bus
+	m.mu.RUnlock()
+
+	if bus == nil {
+		return
+	}
+
+	for _, d := range deltas {
+		bus.Publish(stream.Event{
+			Type: stream.EventMatchboxUpdated,
+			Payload: stream.MatchboxUpdatedPayload{
+				BoardState: d.BoardState,
+				BeadDelta:  map[string]int{strconv.Itoa(d.Position): d.Change},
+				Beads:      intKeysToStrings(d.BeadsAfter),
+			},
+		})
+	}
+
+	bus.Publish(stream.Event{
+		Type: stream.EventLearningApplied,
+		Payload: stream.LearningAppliedPayload{
+			Result:            string(result),
+			MatchboxesTouched: len(deltas),
+		},
+	})
+
+	if snapshotTaken {
+		bus.Publish(stream.Event{
+			Type: stream.EventHistorySnapshot,
+			Payload: stream.HistorySnapshotPayload{
+				Games:         snapshot.Games,
+				TotalBeads:    snapshot.TotalBeads,
+				MatchboxCount: snapshot.MatchboxCount,
+				Wins:          snapshot.Wins,
+				Losses:        snapshot.Losses,
+				Draws:         snapshot.Draws,
+				WinRate:       snapshot.WinRate,
+			},
+		})
+	}
+}
+
+func intKeysToStrings(beads map[int]int) map[string]int {
+	out := make(map[string]int, len(beads))
+	for k, v := range beads {
+		out[strconv.Itoa(k)] = v
+	}
+	return out
+}
@@ -26,6 +26,7 @@ import (
 	"sync"
 
 	"github.com/winzerprince/menace/backend/go/pkg/board"
+	"github.com/winzerprince/menace/backend/go/pkg/stream"
 )
 
 // Matchbox represents a matchbox for a specific board state
@@ -96,6 +97,16 @@ func (m *Matchbox) GetTotalBeads() int {
 	return total
 }
 
+// Clone returns a deep copy of m, safe to hand to a store or another
+// goroutine without the two of them sharing the underlying Beads map.
+func (m *Matchbox) Clone() *Matchbox {
+	beads := make(map[int]int, len(m.Beads))
+	for pos, count := range m.Beads {
+		beads[pos] = count
+	}
+	return &Matchbox{BoardState: m.BoardState, Beads: beads, TimesUsed: m.TimesUsed}
+}
+
 // GetProbabilities returns the probability of each move
 func (m *Matchbox) GetProbabilities() map[int]float64 {
 	total := m.GetTotalBeads()
@@ -125,6 +136,11 @@ type HistorySnapshot struct {
 	Losses        int     `json:"losses"`
 	Draws         int     `json:"draws"`
 	WinRate       float64 `json:"win_rate"`
+
+	// OptimalFraction is the fraction of matchboxes whose highest-bead
+	// move agrees with solver.Solver's optimal move set, as of the last
+	// AnnotateHistory call. Zero until annotated.
+	OptimalFraction float64 `json:"optimal_fraction"`
 }
 
 // Menace is the MENACE machine learning agent
@@ -147,6 +163,16 @@ type Menace struct {
 	Wins        int
 	Losses      int
 	Draws       int
+
+	// Persistence, set via AttachStore. Nil store means purely in-memory,
+	// which is the zero-value behavior NewMenace gives you.
+	store    MatchboxStore
+	syncMode SyncMode
+	asyncCh  chan matchboxWrite
+
+	// bus, set via AttachEventBus, receives move/matchbox/learning
+	// telemetry. Nil means no telemetry is published.
+	bus stream.EventBus
 }
 
 // NewMenace creates a new MENACE agent
@@ -168,6 +194,31 @@ func NewMenace(player board.Player) *Menace {
 	}
 }
 
+// OverrideRewards temporarily overrides WinReward, DrawReward, and
+// LossPenalty, leaving any nil argument unchanged, and returns a restore
+// func that undoes the change. SelfPlayTraining uses this to let a single
+// request body override reward magnitudes for just that training run.
+func (m *Menace) OverrideRewards(win, draw, loss *int) (restore func()) {
+	m.mu.Lock()
+	prevWin, prevDraw, prevLoss := m.WinReward, m.DrawReward, m.LossPenalty
+	if win != nil {
+		m.WinReward = *win
+	}
+	if draw != nil {
+		m.DrawReward = *draw
+	}
+	if loss != nil {
+		m.LossPenalty = *loss
+	}
+	m.mu.Unlock()
+
+	return func() {
+		m.mu.Lock()
+		m.WinReward, m.DrawReward, m.LossPenalty = prevWin, prevDraw, prevLoss
+		m.mu.Unlock()
+	}
+}
+
 // GetOrCreateMatchbox gets or creates a matchbox for a normalized state
 func (m *Menace) GetOrCreateMatchbox(normalizedState string, validMoves []int) *Matchbox {
 	m.mu.Lock()
@@ -182,8 +233,30 @@ func (m *Menace) GetOrCreateMatchbox(normalizedState string, validMoves []int) *
 	return mb
 }
 
+// Decision records one MENACE move for a caller that wants to inspect why
+// it was chosen: the normalized board state consulted, a snapshot of the
+// bead counts MENACE saw before drawing, and the move it drew (on the
+// original, non-normalized board). game.Game uses this to build a per-game
+// decision trace.
+type Decision struct {
+	BoardState    string      `json:"board_state"`
+	BeadsSnapshot map[int]int `json:"beads_snapshot"`
+	ChosenMove    int         `json:"chosen_move"`
+}
+
 // GetMove selects a move for the given board state
 func (m *Menace) GetMove(b *board.Board) int {
+	pos, _ := m.getMoveAndDecision(b)
+	return pos
+}
+
+// GetMoveWithDecision behaves like GetMove, but also returns the Decision
+// describing the choice it made.
+func (m *Menace) GetMoveWithDecision(b *board.Board) (int, Decision) {
+	return m.getMoveAndDecision(b)
+}
+
+func (m *Menace) getMoveAndDecision(b *board.Board) (int, Decision) {
 	// Normalize the board
 	normalizedState, transformIdx := b.Normalize()
 
@@ -193,7 +266,7 @@ func (m *Menace) GetMove(b *board.Board) int {
 	// Transform valid moves to normalized board positions
 	normalizedMoves := make([]int, len(originalMoves))
 	for i, pos := range originalMoves {
-		normalizedMoves[i] = board.TransformPosition(pos, transformIdx)
+		normalizedMoves[i] = b.TransformPosition(pos, transformIdx)
 	}
 
 	// Get or create matchbox for normalized state
@@ -201,13 +274,17 @@ func (m *Menace) GetMove(b *board.Board) int {
 
 	m.mu.Lock()
 	matchbox.TimesUsed++
+	beadsSnapshot := make(map[int]int, len(matchbox.Beads))
+	for pos, count := range matchbox.Beads {
+		beadsSnapshot[pos] = count
+	}
 	m.mu.Unlock()
 
 	// Draw a bead (position on normalized board)
 	normalizedPos := matchbox.DrawBead()
 
 	// Transform back to original board position
-	originalPos := board.InverseTransformPosition(normalizedPos, transformIdx)
+	originalPos := b.InverseTransformPosition(normalizedPos, transformIdx)
 
 	// Record this move for later learning
 	m.mu.Lock()
@@ -218,13 +295,45 @@ func (m *Menace) GetMove(b *board.Board) int {
 	})
 	m.mu.Unlock()
 
-	return originalPos
+	return originalPos, Decision{
+		BoardState:    normalizedState,
+		BeadsSnapshot: beadsSnapshot,
+		ChosenMove:    originalPos,
+	}
 }
 
-// Learn applies learning after a game ends
-func (m *Menace) Learn(result board.GameResult) {
+// ObserveMove records a move MENACE didn't choose itself - a human move in
+// a vs_human game.Game - into MoveHistory exactly as GetMove would have,
+// so the normal Learn reinforcement applies to it at game end. Unlike
+// GetMove, it doesn't draw a bead or return anything; it just grows the
+// matchbox's bead pool at position if that position hasn't been seen yet.
+func (m *Menace) ObserveMove(b *board.Board, position int) {
+	normalizedState, transformIdx := b.Normalize()
+
+	originalMoves := b.GetEmptyPositions()
+	normalizedMoves := make([]int, len(originalMoves))
+	for i, pos := range originalMoves {
+		normalizedMoves[i] = b.TransformPosition(pos, transformIdx)
+	}
+
+	m.GetOrCreateMatchbox(normalizedState, normalizedMoves)
+
+	normalizedPos := b.TransformPosition(position, transformIdx)
+
+	m.mu.Lock()
+	m.MoveHistory = append(m.MoveHistory, MoveRecord{
+		BoardState:   normalizedState,
+		Position:     normalizedPos,
+		TransformIdx: transformIdx,
+	})
+	m.mu.Unlock()
+}
+
+// Learn applies learning after a game ends, and returns the BeadDelta
+// applied to each matchbox touched during the game - the reinforcement
+// trace game.Game records alongside its decision trace.
+func (m *Menace) Learn(result board.GameResult) []BeadDelta {
 	m.mu.Lock()
-	defer m.mu.Unlock()
 
 	m.GamesPlayed++
 
@@ -239,12 +348,16 @@ func (m *Menace) Learn(result board.GameResult) {
 	}
 
 	// Apply rewards/penalties to each move made
+	touched := make([]string, 0, len(m.MoveHistory))
+	deltas := make([]BeadDelta, 0, len(m.MoveHistory))
 	for _, move := range m.MoveHistory {
 		mb := m.Matchboxes[move.BoardState]
 		if mb == nil {
 			continue
 		}
+		touched = append(touched, move.BoardState)
 
+		before := mb.Beads[move.Position]
 		switch result {
 		case board.ResultWin:
 			mb.AddBeads(move.Position, m.WinReward)
@@ -253,20 +366,37 @@ func (m *Menace) Learn(result board.GameResult) {
 		case board.ResultLoss:
 			mb.RemoveBeads(move.Position, m.LossPenalty, m.MinBeads)
 		}
+		deltas = append(deltas, BeadDelta{
+			BoardState: move.BoardState,
+			Position:   move.Position,
+			Change:     mb.Beads[move.Position] - before,
+			BeadsAfter: mb.Clone().Beads,
+		})
 	}
 
 	// Clear move history for next game
 	m.MoveHistory = make([]MoveRecord, 0)
 
 	// Record history snapshot
-	m.recordHistorySnapshot()
+	snapshot, snapshotTaken := m.recordHistorySnapshot()
+
+	m.mu.Unlock()
+
+	// Persist, if a store is attached, and publish telemetry, if an event
+	// bus is attached. Both done outside the lock above so neither store
+	// I/O nor a slow subscriber can hold up other goroutines waiting on
+	// Menace's mutex.
+	m.syncAfterLearn(touched)
+	m.publishLearnEvents(result, deltas, snapshot, snapshotTaken)
+
+	return deltas
 }
 
-// recordHistorySnapshot records current state for graphing
-func (m *Menace) recordHistorySnapshot() {
-	// Only record every 10 games to avoid too much data
+// recordHistorySnapshot records current state for graphing, every 10
+// games. It returns the snapshot and whether one was actually recorded.
+func (m *Menace) recordHistorySnapshot() (HistorySnapshot, bool) {
 	if m.GamesPlayed%10 != 0 {
-		return
+		return HistorySnapshot{}, false
 	}
 
 	winRate := 0.0
@@ -274,7 +404,7 @@ func (m *Menace) recordHistorySnapshot() {
 		winRate = float64(m.Wins) / float64(m.GamesPlayed)
 	}
 
-	m.History = append(m.History, HistorySnapshot{
+	snapshot := HistorySnapshot{
 		Games:         m.GamesPlayed,
 		TotalBeads:    m.getTotalBeadsUnsafe(),
 		MatchboxCount: len(m.Matchboxes),
@@ -282,7 +412,9 @@ func (m *Menace) recordHistorySnapshot() {
 		Losses:        m.Losses,
 		Draws:         m.Draws,
 		WinRate:       winRate,
-	})
+	}
+	m.History = append(m.History, snapshot)
+	return snapshot, true
 }
 
 // StartNewGame clears move history for a new game
@@ -370,6 +502,21 @@ func (m *Menace) GetMatchboxData(boardState string) map[string]interface{} {
 	}
 }
 
+// MatchboxesSnapshot returns a deep copy of every matchbox, safe for a
+// caller to range over and format a response from without holding m.mu -
+// like GetMatchboxData, but for every state at once. Used by handlers that
+// need to list all matchboxes (GET /api/menace/matchboxes).
+func (m *Menace) MatchboxesSnapshot() map[string]*Matchbox {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	snapshot := make(map[string]*Matchbox, len(m.Matchboxes))
+	for state, mb := range m.Matchboxes {
+		snapshot[state] = mb.Clone()
+	}
+	return snapshot
+}
+
 // Reset resets MENACE to initial state
 func (m *Menace) Reset() {
 	m.mu.Lock()
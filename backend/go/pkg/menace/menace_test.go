@@ -0,0 +1,86 @@
+package menace
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/winzerprince/menace/backend/go/pkg/board"
+)
+
+// TestLearnBeadDeltaIsSnapshot guards against the regression where
+// BeadDelta.BeadsAfter aliased the matchbox's live Beads map: a later game
+// or training run touching the same board state would silently mutate a
+// finished game's already-recorded reinforcement trace. BeadsAfter must be
+// a frozen copy, unaffected by further beads added to the same matchbox.
+func TestLearnBeadDeltaIsSnapshot(t *testing.T) {
+	m := NewMenace(board.PlayerX)
+	b := board.NewEmpty()
+	pos := m.GetMove(b)
+	if pos < 0 {
+		t.Fatalf("GetMove returned no move on an empty board")
+	}
+
+	deltas := m.Learn(board.ResultWin)
+	if len(deltas) != 1 {
+		t.Fatalf("got %d deltas, want 1", len(deltas))
+	}
+	before := make(map[int]int, len(deltas[0].BeadsAfter))
+	for pos, count := range deltas[0].BeadsAfter {
+		before[pos] = count
+	}
+
+	// Reinforce the same matchbox again via a second game through the same
+	// opening move - this must not change the first delta's snapshot.
+	m.GetMove(board.NewEmpty())
+	m.Learn(board.ResultWin)
+
+	for p, count := range before {
+		if deltas[0].BeadsAfter[p] != count {
+			t.Errorf("deltas[0].BeadsAfter[%d] = %d after a later Learn, want unchanged %d - BeadsAfter is aliasing the live matchbox", p, deltas[0].BeadsAfter[p], count)
+		}
+	}
+}
+
+// TestMatchboxesSnapshotDoesNotRaceWithLearn guards against the regression
+// where a caller (ListMatchboxes) ranged over Menace.Matchboxes directly
+// with no lock: concurrent with Learn's m.mu-guarded writes, that's a
+// "concurrent map iteration and map write" fatal error, not just a benign
+// race. Run with -race to catch it.
+func TestMatchboxesSnapshotDoesNotRaceWithLearn(t *testing.T) {
+	m := NewMenace(board.PlayerX)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for g := 0; g < 50; g++ {
+			b := board.NewEmpty()
+			for i := 0; i < 9 && !b.IsGameOver(); i++ {
+				pos := m.GetMove(b)
+				if pos < 0 {
+					break
+				}
+				player := board.PlayerX
+				if i%2 == 1 {
+					player = board.PlayerO
+				}
+				next, err := b.MakeMove(pos, player)
+				if err != nil {
+					break
+				}
+				b = next
+			}
+			m.Learn(board.ResultDraw)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			for state, mb := range m.MatchboxesSnapshot() {
+				_ = state
+				_ = mb.Beads
+			}
+		}
+	}()
+	wg.Wait()
+}
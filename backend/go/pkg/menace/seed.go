@@ -0,0 +1,108 @@
+package menace
+
+import (
+	"github.com/winzerprince/menace/backend/go/pkg/solver"
+)
+
+// SeedFromSolver pre-populates Matchboxes with extra beads on every
+// game-theoretically optimal move the solver found, so cold-start play
+// isn't uniformly random. Matchboxes that don't exist yet are created
+// (via GetOrCreateMatchbox, so they start with the usual InitialBeads on
+// every valid move); existing beads on non-optimal moves are left
+// untouched. Call this once, before training begins.
+func (m *Menace) SeedFromSolver(s *solver.Solver, weight int) {
+	for _, state := range s.States() {
+		entry, ok := s.Lookup(state)
+		if !ok || len(entry.BestMoves) == 0 {
+			continue
+		}
+
+		mb := m.GetOrCreateMatchbox(state, validMovesFromState(state))
+
+		m.mu.Lock()
+		for _, pos := range entry.BestMoves {
+			mb.AddBeads(pos, weight)
+		}
+		m.mu.Unlock()
+	}
+}
+
+// validMovesFromState returns every empty position in a normalized board
+// state string, in the same coordinates the solver's Entry.BestMoves uses -
+// the positions a fresh Matchbox for that state needs beads on.
+func validMovesFromState(state string) []int {
+	moves := make([]int, 0, len(state))
+	for i, c := range state {
+		if c == '_' {
+			moves = append(moves, i)
+		}
+	}
+	return moves
+}
+
+// AnnotateHistory tags the most recent HistorySnapshot with the fraction of
+// matchboxes whose highest-bead move(s) agree with the solver's optimal
+// move set, giving a "how close to optimal" curve alongside win rate. It is
+// a no-op if no snapshot has been recorded yet.
+func (m *Menace) AnnotateHistory(s *solver.Solver) {
+	fraction := m.optimalFraction(s)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.History) == 0 {
+		return
+	}
+	m.History[len(m.History)-1].OptimalFraction = fraction
+}
+
+// optimalFraction computes, over all current matchboxes, the share whose
+// top move(s) by bead count overlap the solver's optimal move set for that
+// state. States the solver never reached (shouldn't happen if the solver
+// was fully solved) are skipped rather than counted against MENACE.
+func (m *Menace) optimalFraction(s *solver.Solver) float64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if len(m.Matchboxes) == 0 {
+		return 0
+	}
+
+	considered := 0
+	matching := 0
+	for state, mb := range m.Matchboxes {
+		entry, ok := s.Lookup(state)
+		if !ok {
+			continue
+		}
+		considered++
+		if topMovesOverlap(mb, entry.BestMoves) {
+			matching++
+		}
+	}
+	if considered == 0 {
+		return 0
+	}
+	return float64(matching) / float64(considered)
+}
+
+// topMovesOverlap reports whether any of a matchbox's highest-bead moves
+// (there may be ties) appears in optimalMoves.
+func topMovesOverlap(mb *Matchbox, optimalMoves []int) bool {
+	maxBeads := -1
+	for _, count := range mb.Beads {
+		if count > maxBeads {
+			maxBeads = count
+		}
+	}
+	for pos, count := range mb.Beads {
+		if count != maxBeads {
+			continue
+		}
+		for _, opt := range optimalMoves {
+			if pos == opt {
+				return true
+			}
+		}
+	}
+	return false
+}
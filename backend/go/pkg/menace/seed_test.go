@@ -0,0 +1,57 @@
+package menace
+
+import (
+	"testing"
+
+	"github.com/winzerprince/menace/backend/go/pkg/board"
+	"github.com/winzerprince/menace/backend/go/pkg/solver"
+)
+
+// TestSeedFromSolverColdStart guards against the regression where
+// SeedFromSolver only mutated matchboxes that already existed: called on a
+// fresh Menace (its documented use case - "call this once, before training
+// begins"), it was a complete no-op. Seeding must create matchboxes for
+// solved states, with extra beads on the solver's optimal moves.
+func TestSeedFromSolverColdStart(t *testing.T) {
+	s := solver.New()
+	s.Solve()
+
+	m := NewMenace(board.PlayerX)
+	if got := len(m.Matchboxes); got != 0 {
+		t.Fatalf("fresh Menace has %d matchboxes, want 0", got)
+	}
+
+	const weight = 5
+	m.SeedFromSolver(s, weight)
+
+	if len(m.Matchboxes) == 0 {
+		t.Fatalf("SeedFromSolver left Matchboxes empty - still a no-op on cold start")
+	}
+
+	wantStates := 0
+	for _, state := range s.States() {
+		if entry, ok := s.Lookup(state); ok && len(entry.BestMoves) > 0 {
+			wantStates++
+		}
+	}
+	if len(m.Matchboxes) != wantStates {
+		t.Fatalf("got %d matchboxes, want one per non-terminal solved state (%d)", len(m.Matchboxes), wantStates)
+	}
+
+	empty := board.NewEmpty()
+	normalized, _ := empty.Normalize()
+	mb, ok := m.Matchboxes[normalized]
+	if !ok {
+		t.Fatalf("no matchbox created for the empty board state %q", normalized)
+	}
+
+	entry, ok := s.Lookup(normalized)
+	if !ok || len(entry.BestMoves) == 0 {
+		t.Fatalf("solver has no entry/best moves for the empty board - test setup is broken")
+	}
+	for _, pos := range entry.BestMoves {
+		if got := mb.Beads[pos]; got != m.InitialBeads+weight {
+			t.Errorf("Beads[%d] = %d, want InitialBeads(%d)+weight(%d) = %d", pos, got, m.InitialBeads, weight, m.InitialBeads+weight)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package menace
+
+// SyncMode controls when AttachStore writes matchbox changes to a
+// MatchboxStore.
+type SyncMode int
+
+const (
+	// Snapshot dumps the full Menace state to the store once, at the end
+	// of Learn.
+	Snapshot SyncMode = iota
+	// WriteThrough flushes only the matchboxes touched during the game
+	// that just ended, synchronously, at the end of Learn.
+	WriteThrough
+	// Async queues touched matchboxes for a background goroutine to
+	// write, so Learn never blocks on store I/O.
+	Async
+)
+
+// MatchboxStore persists Menace's matchboxes. It is defined here, rather
+// than in pkg/store where its implementations live, because its methods
+// need *Menace and *Matchbox as parameters; pkg/store importing pkg/menace
+// for that would make pkg/menace importing pkg/store's interface a cycle.
+//
+// Attaching a store lets a long training run survive restarts, and lets
+// multiple Menace processes share a matchbox set — something the in-memory
+// map[string]*Matchbox on its own cannot do.
+type MatchboxStore interface {
+	// Save persists every matchbox and the learning statistics in m.
+	Save(m *Menace) error
+	// Load replaces m's matchboxes and statistics with the stored state.
+	Load(m *Menace) error
+	// SaveMatchbox persists a single matchbox, keyed by its normalized
+	// board state.
+	SaveMatchbox(state string, mb *Matchbox) error
+	// LoadMatchbox retrieves a single matchbox by normalized board state.
+	// It returns (nil, nil) if no such matchbox has been saved.
+	LoadMatchbox(state string) (*Matchbox, error)
+	// Iterate calls fn once per stored matchbox. Iteration stops and
+	// Iterate returns the error if fn returns a non-nil error.
+	Iterate(fn func(state string, mb *Matchbox) error) error
+}
+
+type matchboxWrite struct {
+	state string
+	mb    *Matchbox
+}
+
+// AttachStore wires a MatchboxStore into m under the given SyncMode. Any
+// previously attached store (and its async writer goroutine, if any) is
+// detached first. AttachStore does not itself load state from s — call
+// Load via the store, then AttachStore, if you want to resume a prior run.
+func (m *Menace) AttachStore(s MatchboxStore, mode SyncMode) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.asyncCh != nil {
+		close(m.asyncCh)
+		m.asyncCh = nil
+	}
+
+	m.store = s
+	m.syncMode = mode
+
+	if s != nil && mode == Async {
+		ch := make(chan matchboxWrite, 256)
+		m.asyncCh = ch
+		go runAsyncWriter(s, ch)
+	}
+}
+
+// runAsyncWriter drains writes queued by syncAfterLearn until ch is closed
+// by a later AttachStore call.
+func runAsyncWriter(s MatchboxStore, ch chan matchboxWrite) {
+	for write := range ch {
+		_ = s.SaveMatchbox(write.state, write.mb)
+	}
+}
+
+// syncAfterLearn persists the game just played according to the attached
+// store's SyncMode. It is called after Learn releases m.mu, so it's free to
+// take the lock itself for the snapshots it needs.
+func (m *Menace) syncAfterLearn(touchedStates []string) {
+	m.mu.RLock()
+	store := m.store
+	mode := m.syncMode
+	asyncCh := m.asyncCh
+	m.mu.RUnlock()
+
+	if store == nil {
+		return
+	}
+
+	switch mode {
+	case Snapshot:
+		_ = store.Save(m.SnapshotForSave())
+
+	case WriteThrough:
+		for _, state := range touchedStates {
+			if mb := m.matchboxSnapshot(state); mb != nil {
+				_ = store.SaveMatchbox(state, mb)
+			}
+		}
+
+	case Async:
+		if asyncCh == nil {
+			return
+		}
+		for _, state := range touchedStates {
+			mb := m.matchboxSnapshot(state)
+			if mb == nil {
+				continue
+			}
+			select {
+			case asyncCh <- matchboxWrite{state: state, mb: mb}:
+			default:
+				// Writer is backed up; drop rather than block Learn.
+			}
+		}
+	}
+}
+
+// matchboxSnapshot returns a deep copy of the current matchbox for state, or
+// nil if it no longer exists. It clones under m.mu so the returned Matchbox
+// shares no memory with the live one a concurrent Learn call might still be
+// mutating.
+func (m *Menace) matchboxSnapshot(state string) *Matchbox {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	mb, ok := m.Matchboxes[state]
+	if !ok {
+		return nil
+	}
+	return mb.Clone()
+}
+
+// SnapshotForSave returns a *Menace holding a deep copy of every field a
+// MatchboxStore.Save reads (Matchboxes and the game-count statistics),
+// taken under m.mu.RLock so the store never observes a map or Beads being
+// mutated by a concurrent Learn call. It's only safe to pass to a store's
+// Save, not to use as a general-purpose Menace - fields a store doesn't
+// read (MoveHistory, History, ...) are left zero-valued. Callers outside
+// this package (e.g. a shutdown hook persisting the final state) should
+// use this instead of passing a live *Menace straight to Save.
+func (m *Menace) SnapshotForSave() *Menace {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	matchboxes := make(map[string]*Matchbox, len(m.Matchboxes))
+	for state, mb := range m.Matchboxes {
+		matchboxes[state] = mb.Clone()
+	}
+
+	return &Menace{
+		Matchboxes:  matchboxes,
+		GamesPlayed: m.GamesPlayed,
+		Wins:        m.Wins,
+		Losses:      m.Losses,
+		Draws:       m.Draws,
+	}
+}
@@ -0,0 +1,86 @@
+package menace
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/winzerprince/menace/backend/go/pkg/board"
+)
+
+// jsonRoundTripStore is a MatchboxStore that proves its point by actually
+// marshaling what it's handed - a live, concurrently-mutated map or Beads
+// value fails under the race detector during encoding/json's internal map
+// iteration, which is exactly what a real store's Save/SaveMatchbox would
+// hit in production.
+type jsonRoundTripStore struct{}
+
+func (jsonRoundTripStore) Save(m *Menace) error {
+	_, err := json.Marshal(m.Matchboxes)
+	return err
+}
+
+func (jsonRoundTripStore) Load(m *Menace) error { return nil }
+
+func (jsonRoundTripStore) SaveMatchbox(state string, mb *Matchbox) error {
+	_, err := json.Marshal(mb)
+	return err
+}
+
+func (jsonRoundTripStore) LoadMatchbox(state string) (*Matchbox, error) { return nil, nil }
+
+func (jsonRoundTripStore) Iterate(fn func(state string, mb *Matchbox) error) error { return nil }
+
+// TestConcurrentLearnWithStoreDoesNotRace guards against the regression
+// where syncAfterLearn handed a store the live Matchboxes map and live
+// Beads maps with no synchronization: since Handler shares one *Menace
+// across concurrent HTTP requests, two games finishing at once raced a
+// store's encode against Learn's next game mutating the same maps. Run
+// with -race to catch it.
+func TestConcurrentLearnWithStoreDoesNotRace(t *testing.T) {
+	for _, mode := range []SyncMode{Snapshot, WriteThrough, Async} {
+		t.Run(modeName(mode), func(t *testing.T) {
+			m := NewMenace(board.PlayerX)
+			m.AttachStore(jsonRoundTripStore{}, mode)
+
+			var wg sync.WaitGroup
+			for g := 0; g < 20; g++ {
+				wg.Add(1)
+				go func(seed int) {
+					defer wg.Done()
+					b := board.NewEmpty()
+					for i := 0; i < 9 && !b.IsGameOver(); i++ {
+						pos := m.GetMove(b)
+						if pos < 0 {
+							break
+						}
+						player := board.PlayerX
+						if i%2 == 1 {
+							player = board.PlayerO
+						}
+						next, err := b.MakeMove(pos, player)
+						if err != nil {
+							break
+						}
+						b = next
+					}
+					m.Learn(board.ResultDraw)
+				}(g)
+			}
+			wg.Wait()
+		})
+	}
+}
+
+func modeName(mode SyncMode) string {
+	switch mode {
+	case Snapshot:
+		return "Snapshot"
+	case WriteThrough:
+		return "WriteThrough"
+	case Async:
+		return "Async"
+	default:
+		return "unknown"
+	}
+}
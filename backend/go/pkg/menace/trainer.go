@@ -0,0 +1,181 @@
+package menace
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/winzerprince/menace/backend/go/pkg/board"
+	"github.com/winzerprince/menace/backend/go/pkg/opponent"
+)
+
+// TrainerConfig controls a bulk self-play run.
+type TrainerConfig struct {
+	// Games is the training budget in games played. Zero means unbounded
+	// (rely on MaxDuration instead).
+	Games int
+	// MaxDuration is a wall-time budget. Zero means unbounded (rely on
+	// Games instead). If both are set, training stops at whichever is hit
+	// first.
+	MaxDuration time.Duration
+
+	// Opponent is who Primary trains against. Leave nil to train
+	// Primary against Secondary instead (MENACE-vs-MENACE).
+	Opponent opponent.Opponent
+
+	// Epsilon is the exploration rate: with this probability a player
+	// ignores its matchbox beads and plays a uniformly random valid move,
+	// so that rare beads in a peaky matchbox still get drawn occasionally.
+	Epsilon float64
+}
+
+// TrainerReport summarizes a completed training run.
+type TrainerReport struct {
+	GamesPlayed int
+	Wins        int
+	Losses      int
+	Draws       int
+	Elapsed     time.Duration
+}
+
+// Trainer runs bulk self-play games to bootstrap a Menace agent before it
+// faces a human. Primary is the agent being trained. Secondary, if set, is
+// a second independently-learning agent playing the opposite side instead
+// of Config.Opponent (one Menace instance per side, board.Player swapped).
+type Trainer struct {
+	Primary   *Menace
+	Secondary *Menace
+	Config    TrainerConfig
+}
+
+// NewTrainer creates a Trainer for primary training against cfg.Opponent.
+func NewTrainer(primary *Menace, cfg TrainerConfig) *Trainer {
+	return &Trainer{Primary: primary, Config: cfg}
+}
+
+// NewSelfPlayTrainer creates a Trainer pitting two independently-learning
+// Menace instances against each other. secondary must be playing the
+// opposite board.Player from primary.
+func NewSelfPlayTrainer(primary, secondary *Menace, cfg TrainerConfig) *Trainer {
+	cfg.Opponent = nil
+	return &Trainer{Primary: primary, Secondary: secondary, Config: cfg}
+}
+
+// Run plays games until the configured budget is exhausted, reporting
+// per-epoch win/draw/loss curves into Primary.History (and Secondary.History,
+// when training two agents at once) via the normal Learn snapshotting.
+func (t *Trainer) Run() TrainerReport {
+	start := time.Now()
+	report := TrainerReport{}
+
+	for t.withinBudget(report.GamesPlayed, start) {
+		result := t.playOneGame()
+
+		report.GamesPlayed++
+		switch result {
+		case board.ResultWin:
+			report.Wins++
+		case board.ResultLoss:
+			report.Losses++
+		case board.ResultDraw:
+			report.Draws++
+		}
+	}
+
+	report.Elapsed = time.Since(start)
+	return report
+}
+
+func (t *Trainer) withinBudget(gamesSoFar int, start time.Time) bool {
+	if t.Config.Games > 0 && gamesSoFar >= t.Config.Games {
+		return false
+	}
+	if t.Config.MaxDuration > 0 && time.Since(start) >= t.Config.MaxDuration {
+		return false
+	}
+	if t.Config.Games == 0 && t.Config.MaxDuration == 0 {
+		return false
+	}
+	return true
+}
+
+// playOneGame plays a single game to completion from Primary's perspective
+// and returns the result the way board.Board.GetResult reports it for
+// Primary.Player.
+func (t *Trainer) playOneGame() board.GameResult {
+	t.Primary.StartNewGame()
+	secondPlayer := t.Primary.Player.Other()
+	if t.Secondary != nil {
+		t.Secondary.Player = secondPlayer
+		t.Secondary.StartNewGame()
+	}
+
+	b := board.NewEmpty()
+	current := board.PlayerX
+
+	for !b.IsGameOver() {
+		var pos int
+		switch {
+		case current == t.Primary.Player:
+			pos = t.Primary.GetMoveWithExploration(b, t.Config.Epsilon)
+		case t.Secondary != nil:
+			pos = t.Secondary.GetMoveWithExploration(b, t.Config.Epsilon)
+		case t.Config.Opponent != nil:
+			pos = t.Config.Opponent.Move(b, current)
+		default:
+			pos = opponent.RandomPlayer{}.Move(b, current)
+		}
+
+		next, err := b.MakeMove(pos, current)
+		if err != nil {
+			break
+		}
+		b = next
+		current = current.Other()
+	}
+
+	result := b.GetResult(t.Primary.Player)
+	t.Primary.Learn(result)
+	if t.Secondary != nil {
+		t.Secondary.Learn(b.GetResult(t.Secondary.Player))
+	}
+	return result
+}
+
+// GetMoveWithExploration is GetMove with an epsilon-greedy override: with
+// probability epsilon it plays a uniformly random valid move instead of
+// drawing a weighted bead, so exploration doesn't starve once a matchbox's
+// bead counts become lopsided.
+func (m *Menace) GetMoveWithExploration(b *board.Board, epsilon float64) int {
+	if epsilon > 0 && rand.Float64() < epsilon {
+		moves := b.GetEmptyPositions()
+		if len(moves) == 0 {
+			return -1
+		}
+		pos := moves[rand.Intn(len(moves))]
+		m.recordExploratoryMove(b, pos)
+		return pos
+	}
+	return m.GetMove(b)
+}
+
+// recordExploratoryMove mirrors GetMove's bookkeeping for a move chosen by
+// the epsilon-greedy override rather than a bead draw, so Learn still finds
+// a MoveRecord to reward or punish.
+func (m *Menace) recordExploratoryMove(b *board.Board, position int) {
+	normalizedState, transformIdx := b.Normalize()
+	normalizedPos := b.TransformPosition(position, transformIdx)
+
+	normalizedMoves := make([]int, 0, len(b.GetEmptyPositions()))
+	for _, p := range b.GetEmptyPositions() {
+		normalizedMoves = append(normalizedMoves, b.TransformPosition(p, transformIdx))
+	}
+	m.GetOrCreateMatchbox(normalizedState, normalizedMoves)
+
+	m.mu.Lock()
+	m.MoveHistory = append(m.MoveHistory, MoveRecord{
+		BoardState:   normalizedState,
+		Position:     normalizedPos,
+		TransformIdx: transformIdx,
+	})
+	m.mu.Unlock()
+}
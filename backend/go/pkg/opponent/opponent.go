@@ -0,0 +1,154 @@
+/*
+Package opponent provides board-level opponents that MENACE can train and be
+measured against, independent of any particular game session or transport.
+*/
+package opponent
+
+import (
+	"math/rand"
+
+	"github.com/winzerprince/menace/backend/go/pkg/board"
+)
+
+// Opponent selects a move for a given board on behalf of a single player.
+type Opponent interface {
+	// Move returns the chosen position for `me` to play on `b`.
+	Move(b *board.Board, me board.Player) int
+	// Name identifies the opponent, e.g. for reporting per-opponent stats.
+	Name() string
+}
+
+// RandomPlayer picks a uniformly random empty square.
+type RandomPlayer struct{}
+
+func (RandomPlayer) Move(b *board.Board, me board.Player) int {
+	moves := b.GetEmptyPositions()
+	if len(moves) == 0 {
+		return -1
+	}
+	return moves[rand.Intn(len(moves))]
+}
+
+func (RandomPlayer) Name() string { return "random" }
+
+// PerfectPlayer always plays a game-theoretically optimal move, found via
+// negamax search with alpha-beta pruning over board.WinningLines. Against a
+// PerfectPlayer, no opponent can do better than a draw.
+type PerfectPlayer struct{}
+
+func (PerfectPlayer) Name() string { return "perfect" }
+
+func (PerfectPlayer) Move(b *board.Board, me board.Player) int {
+	moves := b.GetEmptyPositions()
+	if len(moves) == 0 {
+		return -1
+	}
+
+	bestMove := moves[0]
+	bestScore := -2
+	for _, pos := range moves {
+		next, err := b.MakeMove(pos, me)
+		if err != nil {
+			continue
+		}
+		score := -negamax(next, me.Other(), -1, 1)
+		if score > bestScore {
+			bestScore = score
+			bestMove = pos
+		}
+	}
+	return bestMove
+}
+
+// HeuristicPlayer plays the classic tic-tac-toe heuristic: win if possible,
+// otherwise block an immediate opponent win, otherwise take the center,
+// otherwise a corner, otherwise any remaining square. It's fixed and never
+// learns, but noticeably stronger than RandomPlayer and cheaper than
+// PerfectPlayer.
+type HeuristicPlayer struct{}
+
+func (HeuristicPlayer) Name() string { return "heuristic" }
+
+func (HeuristicPlayer) Move(b *board.Board, me board.Player) int {
+	moves := b.GetEmptyPositions()
+	if len(moves) == 0 {
+		return -1
+	}
+
+	if pos, ok := findWinningMove(b, moves, me); ok {
+		return pos
+	}
+	if pos, ok := findWinningMove(b, moves, me.Other()); ok {
+		return pos
+	}
+
+	size := b.Rules().Size()
+	center := size * size / 2
+	for _, pos := range moves {
+		if pos == center {
+			return pos
+		}
+	}
+	for _, pos := range moves {
+		if isCorner(pos, size) {
+			return pos
+		}
+	}
+	return moves[0]
+}
+
+// findWinningMove returns the first move among moves that would immediately
+// win the game for player, if one exists.
+func findWinningMove(b *board.Board, moves []int, player board.Player) (int, bool) {
+	for _, pos := range moves {
+		next, err := b.MakeMove(pos, player)
+		if err != nil {
+			continue
+		}
+		if next.CheckWinner() == player {
+			return pos, true
+		}
+	}
+	return 0, false
+}
+
+// isCorner reports whether pos is one of the four corners of a size*size
+// board.
+func isCorner(pos, size int) bool {
+	r, c := pos/size, pos%size
+	return (r == 0 || r == size-1) && (c == 0 || c == size-1)
+}
+
+// negamax evaluates `b` from `toMove`'s perspective: +1 a forced win for
+// toMove, -1 a forced loss, 0 a draw with best play. alpha/beta bound the
+// search window and are initialized by the caller at (-1, +1).
+func negamax(b *board.Board, toMove board.Player, alpha, beta int) int {
+	if winner := b.CheckWinner(); winner != board.PlayerNone {
+		if winner == toMove {
+			return 1
+		}
+		return -1
+	}
+	if b.IsFull() {
+		return 0
+	}
+
+	best := -2
+	for _, pos := range b.GetEmptyPositions() {
+		next, err := b.MakeMove(pos, toMove)
+		if err != nil {
+			continue
+		}
+		score := -negamax(next, toMove.Other(), -beta, -alpha)
+		if score > best {
+			best = score
+		}
+		if best > alpha {
+			alpha = best
+		}
+		if alpha >= beta {
+			break
+		}
+	}
+	return best
+}
@@ -0,0 +1,126 @@
+/*
+Package solver computes game-theoretically optimal play for tic-tac-toe via
+full negamax search, so MENACE's matchboxes can be seeded with a head start
+and graded against perfect play.
+*/
+package solver
+
+import (
+	"sync"
+
+	"github.com/winzerprince/menace/backend/go/pkg/board"
+)
+
+// Entry is the solved value of one normalized board state from the
+// perspective of the player to move: +1 a forced win, 0 a forced draw with
+// best play, -1 a forced loss. BestMoves lists every move (in normalized
+// board coordinates) that achieves that value.
+type Entry struct {
+	Value     int
+	BestMoves []int
+}
+
+// Solver holds a transposition table of solved normalized states, keyed by
+// board.Normalize() so the 8-way symmetry collapses the tree MENACE would
+// otherwise have to explore one state at a time.
+type Solver struct {
+	mu    sync.RWMutex
+	table map[string]Entry
+}
+
+// New creates an empty Solver. Call Solve to populate it.
+func New() *Solver {
+	return &Solver{table: make(map[string]Entry)}
+}
+
+// Solve walks the full game tree from the empty board, filling the
+// transposition table with every reachable normalized state.
+func (s *Solver) Solve() {
+	s.negamax(board.NewEmpty(), board.PlayerX)
+}
+
+// Lookup returns the solved entry for a normalized board state, if present.
+func (s *Solver) Lookup(normalizedState string) (Entry, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	e, ok := s.table[normalizedState]
+	return e, ok
+}
+
+// Size returns the number of distinct normalized states solved.
+func (s *Solver) Size() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.table)
+}
+
+// States returns every normalized state Solve reached, for callers like
+// Menace.SeedFromSolver that need to walk the whole table rather than
+// look up one state at a time.
+func (s *Solver) States() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	states := make([]string, 0, len(s.table))
+	for state := range s.table {
+		states = append(states, state)
+	}
+	return states
+}
+
+// negamax evaluates b from toMove's perspective via full search, memoizing
+// on the normalized state. It deliberately doesn't alpha-beta prune: a
+// value memoized under a narrowed window isn't safe to reuse from a
+// different window without a bound-type tag (exact/lower/upper), and on a
+// 9-cell board with memoization collapsing symmetric states, the full
+// search is cheap enough not to need it.
+func (s *Solver) negamax(b *board.Board, toMove board.Player) int {
+	normalized, transformIdx := b.Normalize()
+
+	if e, ok := s.Lookup(normalized); ok {
+		return e.Value
+	}
+
+	if winner := b.CheckWinner(); winner != board.PlayerNone {
+		value := -1
+		if winner == toMove {
+			value = 1
+		}
+		s.store(normalized, value, nil)
+		return value
+	}
+	if b.IsFull() {
+		s.store(normalized, 0, nil)
+		return 0
+	}
+
+	best := -2
+	var bestMoves []int
+	for _, pos := range b.GetEmptyPositions() {
+		next, err := b.MakeMove(pos, toMove)
+		if err != nil {
+			continue
+		}
+		score := -s.negamax(next, toMove.Other())
+
+		normalizedPos := b.TransformPosition(pos, transformIdx)
+		if score > best {
+			best = score
+			bestMoves = []int{normalizedPos}
+		} else if score == best {
+			bestMoves = append(bestMoves, normalizedPos)
+		}
+	}
+
+	s.store(normalized, best, bestMoves)
+	return best
+}
+
+func (s *Solver) store(normalized string, value int, bestMoves []int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.table[normalized]; exists {
+		return
+	}
+	s.table[normalized] = Entry{Value: value, BestMoves: bestMoves}
+}
@@ -0,0 +1,206 @@
+package store
+
+import (
+	"encoding/binary"
+	"encoding/json"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/winzerprince/menace/backend/go/pkg/menace"
+)
+
+var (
+	matchboxesBucket = []byte("matchboxes")
+	statsBucket      = []byte("stats")
+)
+
+// BoltStore persists matchboxes in an embedded BoltDB file, one nested
+// bucket per matchbox (keyed by normalized board state) inside a top-level
+// "matchboxes" bucket, plus a "stats" bucket for aggregate learning
+// statistics. Unlike JSONStore, SaveMatchbox/LoadMatchbox touch only that
+// matchbox's bucket, so WriteThrough sync stays cheap as the matchbox set
+// grows.
+type BoltStore struct {
+	db *bolt.DB
+}
+
+// NewBoltStore opens (creating if necessary) a BoltDB file at path.
+func NewBoltStore(path string) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0o600, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists(matchboxesBucket); err != nil {
+			return err
+		}
+		_, err := tx.CreateBucketIfNotExists(statsBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &BoltStore{db: db}, nil
+}
+
+// Close releases the underlying BoltDB file handle.
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *BoltStore) Save(m *menace.Menace) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		boxes := tx.Bucket(matchboxesBucket)
+		for state, mb := range m.Matchboxes {
+			if err := putMatchbox(boxes, state, mb); err != nil {
+				return err
+			}
+		}
+		return putStats(tx.Bucket(statsBucket), m)
+	})
+}
+
+func (s *BoltStore) Load(m *menace.Menace) error {
+	matchboxes := make(map[string]*menace.Matchbox)
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		boxes := tx.Bucket(matchboxesBucket)
+		err := boxes.ForEach(func(state, v []byte) error {
+			if v != nil {
+				return nil // not a nested bucket
+			}
+			mb, err := getMatchbox(boxes, string(state))
+			if err != nil {
+				return err
+			}
+			matchboxes[string(state)] = mb
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		return getStats(tx.Bucket(statsBucket), m)
+	})
+	if err != nil {
+		return err
+	}
+
+	m.Matchboxes = matchboxes
+	return nil
+}
+
+func (s *BoltStore) SaveMatchbox(state string, mb *menace.Matchbox) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return putMatchbox(tx.Bucket(matchboxesBucket), state, mb)
+	})
+}
+
+func (s *BoltStore) LoadMatchbox(state string) (*menace.Matchbox, error) {
+	var mb *menace.Matchbox
+	err := s.db.View(func(tx *bolt.Tx) error {
+		boxes := tx.Bucket(matchboxesBucket)
+		if boxes.Bucket([]byte(state)) == nil {
+			return nil
+		}
+		var err error
+		mb, err = getMatchbox(boxes, state)
+		return err
+	})
+	return mb, err
+}
+
+func (s *BoltStore) Iterate(fn func(state string, mb *menace.Matchbox) error) error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		boxes := tx.Bucket(matchboxesBucket)
+		return boxes.ForEach(func(state, v []byte) error {
+			if v != nil {
+				return nil // not a nested bucket
+			}
+			mb, err := getMatchbox(boxes, string(state))
+			if err != nil {
+				return err
+			}
+			return fn(string(state), mb)
+		})
+	})
+}
+
+func putMatchbox(boxes *bolt.Bucket, state string, mb *menace.Matchbox) error {
+	bucket, err := boxes.CreateBucketIfNotExists([]byte(state))
+	if err != nil {
+		return err
+	}
+	beads, err := json.Marshal(mb.Beads)
+	if err != nil {
+		return err
+	}
+	if err := bucket.Put([]byte("beads"), beads); err != nil {
+		return err
+	}
+	return bucket.Put([]byte("times_used"), itob(mb.TimesUsed))
+}
+
+func getMatchbox(boxes *bolt.Bucket, state string) (*menace.Matchbox, error) {
+	bucket := boxes.Bucket([]byte(state))
+	if bucket == nil {
+		return nil, nil
+	}
+
+	var beads map[int]int
+	if raw := bucket.Get([]byte("beads")); raw != nil {
+		if err := json.Unmarshal(raw, &beads); err != nil {
+			return nil, err
+		}
+	}
+
+	return &menace.Matchbox{
+		BoardState: state,
+		Beads:      beads,
+		TimesUsed:  btoi(bucket.Get([]byte("times_used"))),
+	}, nil
+}
+
+func putStats(bucket *bolt.Bucket, m *menace.Menace) error {
+	raw, err := json.Marshal(snapshotStats{
+		GamesPlayed: m.GamesPlayed,
+		Wins:        m.Wins,
+		Losses:      m.Losses,
+		Draws:       m.Draws,
+	})
+	if err != nil {
+		return err
+	}
+	return bucket.Put([]byte("stats"), raw)
+}
+
+func getStats(bucket *bolt.Bucket, m *menace.Menace) error {
+	raw := bucket.Get([]byte("stats"))
+	if raw == nil {
+		return nil
+	}
+	var stats snapshotStats
+	if err := json.Unmarshal(raw, &stats); err != nil {
+		return err
+	}
+	m.GamesPlayed = stats.GamesPlayed
+	m.Wins = stats.Wins
+	m.Losses = stats.Losses
+	m.Draws = stats.Draws
+	return nil
+}
+
+func itob(v int) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(v))
+	return b
+}
+
+func btoi(b []byte) int {
+	if len(b) != 8 {
+		return 0
+	}
+	return int(binary.BigEndian.Uint64(b))
+}
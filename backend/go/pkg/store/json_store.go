@@ -0,0 +1,135 @@
+package store
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/winzerprince/menace/backend/go/pkg/menace"
+)
+
+// jsonFile is the on-disk shape written by JSONStore.
+type jsonFile struct {
+	Stats      snapshotStats               `json:"stats"`
+	Matchboxes map[string]*menace.Matchbox `json:"matchboxes"`
+}
+
+// JSONStore persists matchboxes as a single JSON file snapshot. It's the
+// simplest backend: cheap for small matchbox sets, but Save/Load rewrite
+// the whole file, so SaveMatchbox/LoadMatchbox (used by WriteThrough and
+// Async sync modes) read-modify-write the entire file too.
+type JSONStore struct {
+	mu   sync.Mutex
+	Path string
+}
+
+// NewJSONStore creates a JSONStore backed by the file at path. The file is
+// created on first Save/SaveMatchbox; it does not need to exist yet.
+func NewJSONStore(path string) *JSONStore {
+	return &JSONStore{Path: path}
+}
+
+func (s *JSONStore) Save(m *menace.Menace) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data := jsonFile{
+		Stats: snapshotStats{
+			GamesPlayed: m.GamesPlayed,
+			Wins:        m.Wins,
+			Losses:      m.Losses,
+			Draws:       m.Draws,
+		},
+		Matchboxes: m.Matchboxes,
+	}
+	return s.write(data)
+}
+
+func (s *JSONStore) Load(m *menace.Menace) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	m.Matchboxes = data.Matchboxes
+	if m.Matchboxes == nil {
+		m.Matchboxes = make(map[string]*menace.Matchbox)
+	}
+	m.GamesPlayed = data.Stats.GamesPlayed
+	m.Wins = data.Stats.Wins
+	m.Losses = data.Stats.Losses
+	m.Draws = data.Stats.Draws
+	return nil
+}
+
+func (s *JSONStore) SaveMatchbox(state string, mb *menace.Matchbox) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := s.read()
+	if err != nil {
+		return err
+	}
+	if data.Matchboxes == nil {
+		data.Matchboxes = make(map[string]*menace.Matchbox)
+	}
+	data.Matchboxes[state] = mb
+	return s.write(data)
+}
+
+func (s *JSONStore) LoadMatchbox(state string) (*menace.Matchbox, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	return data.Matchboxes[state], nil
+}
+
+func (s *JSONStore) Iterate(fn func(state string, mb *menace.Matchbox) error) error {
+	s.mu.Lock()
+	data, err := s.read()
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	for state, mb := range data.Matchboxes {
+		if err := fn(state, mb); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// read loads the JSON file, returning an empty jsonFile if it doesn't
+// exist yet. Caller must hold s.mu.
+func (s *JSONStore) read() (jsonFile, error) {
+	raw, err := os.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return jsonFile{Matchboxes: make(map[string]*menace.Matchbox)}, nil
+	}
+	if err != nil {
+		return jsonFile{}, err
+	}
+
+	var data jsonFile
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return jsonFile{}, err
+	}
+	return data, nil
+}
+
+// write overwrites the JSON file. Caller must hold s.mu.
+func (s *JSONStore) write(data jsonFile) error {
+	raw, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.Path, raw, 0o644)
+}
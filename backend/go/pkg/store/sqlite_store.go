@@ -0,0 +1,188 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/winzerprince/menace/backend/go/pkg/menace"
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS matchboxes (
+	board_state TEXT PRIMARY KEY,
+	beads       TEXT NOT NULL,
+	times_used  INTEGER NOT NULL DEFAULT 0
+);
+CREATE TABLE IF NOT EXISTS stats (
+	id           INTEGER PRIMARY KEY CHECK (id = 0),
+	games_played INTEGER NOT NULL DEFAULT 0,
+	wins         INTEGER NOT NULL DEFAULT 0,
+	losses       INTEGER NOT NULL DEFAULT 0,
+	draws        INTEGER NOT NULL DEFAULT 0
+);
+`
+
+// SQLiteStore persists matchboxes to a SQLite database, one row per
+// matchbox. It's the backend of choice when several Menace processes need
+// to share a matchbox set concurrently, since SQLite serializes writers for
+// you.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteStore opens (creating and migrating if necessary) a SQLite
+// database file at path.
+func NewSQLiteStore(path string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &SQLiteStore{db: db}, nil
+}
+
+// Close releases the underlying database handle.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *SQLiteStore) Save(m *menace.Menace) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for state, mb := range m.Matchboxes {
+		if err := saveMatchboxTx(tx, state, mb); err != nil {
+			return err
+		}
+	}
+	if err := saveStatsTx(tx, m); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *SQLiteStore) Load(m *menace.Menace) error {
+	rows, err := s.db.Query(`SELECT board_state, beads, times_used FROM matchboxes`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	matchboxes := make(map[string]*menace.Matchbox)
+	for rows.Next() {
+		mb, state, err := scanMatchbox(rows.Scan)
+		if err != nil {
+			return err
+		}
+		matchboxes[state] = mb
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	m.Matchboxes = matchboxes
+
+	row := s.db.QueryRow(`SELECT games_played, wins, losses, draws FROM stats WHERE id = 0`)
+	var stats snapshotStats
+	if err := row.Scan(&stats.GamesPlayed, &stats.Wins, &stats.Losses, &stats.Draws); err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	m.GamesPlayed = stats.GamesPlayed
+	m.Wins = stats.Wins
+	m.Losses = stats.Losses
+	m.Draws = stats.Draws
+	return nil
+}
+
+func (s *SQLiteStore) SaveMatchbox(state string, mb *menace.Matchbox) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := saveMatchboxTx(tx, state, mb); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *SQLiteStore) LoadMatchbox(state string) (*menace.Matchbox, error) {
+	row := s.db.QueryRow(`SELECT board_state, beads, times_used FROM matchboxes WHERE board_state = ?`, state)
+
+	var beadsJSON string
+	mb := &menace.Matchbox{BoardState: state}
+	if err := row.Scan(&mb.BoardState, &beadsJSON, &mb.TimesUsed); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(beadsJSON), &mb.Beads); err != nil {
+		return nil, err
+	}
+	return mb, nil
+}
+
+func (s *SQLiteStore) Iterate(fn func(state string, mb *menace.Matchbox) error) error {
+	rows, err := s.db.Query(`SELECT board_state, beads, times_used FROM matchboxes`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		mb, state, err := scanMatchbox(rows.Scan)
+		if err != nil {
+			return err
+		}
+		if err := fn(state, mb); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func saveMatchboxTx(tx *sql.Tx, state string, mb *menace.Matchbox) error {
+	beads, err := json.Marshal(mb.Beads)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`
+		INSERT INTO matchboxes (board_state, beads, times_used) VALUES (?, ?, ?)
+		ON CONFLICT(board_state) DO UPDATE SET beads = excluded.beads, times_used = excluded.times_used`,
+		state, string(beads), mb.TimesUsed)
+	return err
+}
+
+func saveStatsTx(tx *sql.Tx, m *menace.Menace) error {
+	_, err := tx.Exec(`
+		INSERT INTO stats (id, games_played, wins, losses, draws) VALUES (0, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET games_played = excluded.games_played, wins = excluded.wins,
+			losses = excluded.losses, draws = excluded.draws`,
+		m.GamesPlayed, m.Wins, m.Losses, m.Draws)
+	return err
+}
+
+// scanMatchbox reads one (board_state, beads, times_used) row via scan.
+func scanMatchbox(scan func(dest ...interface{}) error) (*menace.Matchbox, string, error) {
+	var state, beadsJSON string
+	var timesUsed int
+	if err := scan(&state, &beadsJSON, &timesUsed); err != nil {
+		return nil, "", err
+	}
+
+	var beads map[int]int
+	if err := json.Unmarshal([]byte(beadsJSON), &beads); err != nil {
+		return nil, "", err
+	}
+
+	return &menace.Matchbox{BoardState: state, Beads: beads, TimesUsed: timesUsed}, state, nil
+}
@@ -0,0 +1,15 @@
+/*
+Package store provides MatchboxStore implementations (see
+pkg/menace.MatchboxStore) so a Menace agent's matchboxes can survive
+process restarts or be shared across processes.
+*/
+package store
+
+// snapshotStats is the portion of Menace's learning statistics persisted
+// alongside its matchboxes by Save/Load, common to every backend.
+type snapshotStats struct {
+	GamesPlayed int `json:"games_played"`
+	Wins        int `json:"wins"`
+	Losses      int `json:"losses"`
+	Draws       int `json:"draws"`
+}
@@ -0,0 +1,55 @@
+package stream
+
+import (
+	"encoding/json"
+
+	"github.com/gorilla/websocket"
+)
+
+// Client consumes a Hub's event stream over WebSocket. It exists so tests
+// (and simple tools) can subscribe without reimplementing the wire format.
+type Client struct {
+	conn   *websocket.Conn
+	Events chan Event
+	Errors chan error
+}
+
+// Dial connects to a Hub's WebSocket endpoint at url and starts reading
+// events in the background. Call Close when done.
+func Dial(url string) (*Client, error) {
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		conn:   conn,
+		Events: make(chan Event, 64),
+		Errors: make(chan error, 1),
+	}
+	go c.readLoop()
+	return c, nil
+}
+
+func (c *Client) readLoop() {
+	defer close(c.Events)
+	for {
+		_, raw, err := c.conn.ReadMessage()
+		if err != nil {
+			c.Errors <- err
+			return
+		}
+
+		var event Event
+		if err := json.Unmarshal(raw, &event); err != nil {
+			c.Errors <- err
+			continue
+		}
+		c.Events <- event
+	}
+}
+
+// Close shuts down the underlying WebSocket connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
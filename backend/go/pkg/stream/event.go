@@ -0,0 +1,75 @@
+/*
+Package stream pushes real-time MENACE telemetry (moves, matchbox updates,
+learning, and training progress) to subscribers over WebSocket, so a UI can
+watch matchboxes change bead counts as games progress instead of polling.
+*/
+package stream
+
+// EventType identifies the kind of telemetry an Event carries.
+type EventType string
+
+const (
+	// EventMoveMade fires whenever a move (human or MENACE) lands on a
+	// game's board. Payload is MovePayload.
+	EventMoveMade EventType = "move_made"
+	// EventMatchboxUpdated fires whenever a matchbox's beads change.
+	// Payload is MatchboxUpdatedPayload.
+	EventMatchboxUpdated EventType = "matchbox_updated"
+	// EventLearningApplied fires once per finished game, after Learn has
+	// applied its rewards/penalties. Payload is LearningAppliedPayload.
+	EventLearningApplied EventType = "learning_applied"
+	// EventHistorySnapshot fires whenever Menace records a new
+	// HistorySnapshot (every 10 games). Payload is HistorySnapshotPayload.
+	EventHistorySnapshot EventType = "history_snapshot"
+)
+
+// Event is the envelope delivered to every subscriber. GameID is empty for
+// events that aren't scoped to one game (e.g. a training-wide history
+// snapshot).
+type Event struct {
+	Type    EventType   `json:"type"`
+	GameID  string      `json:"game_id,omitempty"`
+	Payload interface{} `json:"payload"`
+}
+
+// MovePayload is EventMoveMade's payload.
+type MovePayload struct {
+	Player     string `json:"player"`
+	Position   int    `json:"position"`
+	BoardAfter string `json:"board_after"`
+}
+
+// MatchboxUpdatedPayload is EventMatchboxUpdated's payload. BeadDelta maps
+// position (as a string, for JSON object-key compatibility) to the signed
+// change in bead count.
+type MatchboxUpdatedPayload struct {
+	BoardState string         `json:"board_state"`
+	BeadDelta  map[string]int `json:"bead_delta"`
+	Beads      map[string]int `json:"beads"`
+}
+
+// LearningAppliedPayload is EventLearningApplied's payload.
+type LearningAppliedPayload struct {
+	Result            string `json:"result"`
+	MatchboxesTouched int    `json:"matchboxes_touched"`
+}
+
+// HistorySnapshotPayload is EventHistorySnapshot's payload, mirroring
+// menace.HistorySnapshot.
+type HistorySnapshotPayload struct {
+	Games         int     `json:"games"`
+	TotalBeads    int     `json:"total_beads"`
+	MatchboxCount int     `json:"matchbox_count"`
+	Wins          int     `json:"wins"`
+	Losses        int     `json:"losses"`
+	Draws         int     `json:"draws"`
+	WinRate       float64 `json:"win_rate"`
+}
+
+// EventBus is anything that can accept published events. pkg/menace and
+// pkg/game depend on this interface (not on *Hub) so the core learning and
+// game logic stays decoupled from the WebSocket transport - in tests, a
+// fake EventBus can just collect Events in a slice.
+type EventBus interface {
+	Publish(event Event)
+}
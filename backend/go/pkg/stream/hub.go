@@ -0,0 +1,113 @@
+package stream
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/gorilla/websocket"
+)
+
+var upgrader = websocket.Upgrader{
+	// Handlers embedding the Hub are responsible for CORS; the Hub itself
+	// just accepts any origin, matching the permissive local-dev CORS
+	// config used elsewhere in this API.
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// subscriber is one open WebSocket connection, subscribed either to a
+// single game (gameID != "") or globally (gameID == "").
+type subscriber struct {
+	gameID string
+	outbox chan Event
+}
+
+// Hub is an EventBus that fans published Events out to every subscriber:
+// global subscribers see everything, per-game subscribers only see events
+// for their gameID (events with an empty GameID, like history snapshots,
+// go to every subscriber regardless).
+type Hub struct {
+	mu          sync.RWMutex
+	subscribers map[*subscriber]struct{}
+}
+
+// NewHub creates an empty Hub.
+func NewHub() *Hub {
+	return &Hub{subscribers: make(map[*subscriber]struct{})}
+}
+
+// Publish implements EventBus by fanning event out to every matching
+// subscriber's outbox. A slow or dead subscriber never blocks Publish: its
+// outbox is buffered, and a full outbox just drops the event for that
+// subscriber.
+func (h *Hub) Publish(event Event) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for sub := range h.subscribers {
+		if sub.gameID != "" && event.GameID != "" && sub.gameID != event.GameID {
+			continue
+		}
+		select {
+		case sub.outbox <- event:
+		default:
+		}
+	}
+}
+
+// ServeWS upgrades r to a WebSocket and streams events to it until the
+// client disconnects. gameID scopes the subscription to one game; pass ""
+// to subscribe globally (used for the training stream).
+//
+// onMessage, if non-nil, is called with each inbound message's raw bytes -
+// e.g. a move pushed by the client instead of a separate REST call. Pass
+// nil for a pure push subscriber; gorilla's ping/pong and close handling
+// still needs inbound messages drained either way, so ServeWS always reads.
+func (h *Hub) ServeWS(w http.ResponseWriter, r *http.Request, gameID string, onMessage func(raw []byte)) error {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	sub := &subscriber{gameID: gameID, outbox: make(chan Event, 64)}
+	h.add(sub)
+	defer h.remove(sub)
+
+	go func() {
+		for {
+			_, raw, err := conn.ReadMessage()
+			if err != nil {
+				conn.Close()
+				return
+			}
+			if onMessage != nil {
+				onMessage(raw)
+			}
+		}
+	}()
+
+	for event := range sub.outbox {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			continue
+		}
+		if err := conn.WriteMessage(websocket.TextMessage, payload); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *Hub) add(sub *subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.subscribers[sub] = struct{}{}
+}
+
+func (h *Hub) remove(sub *subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.subscribers, sub)
+	close(sub.outbox)
+}
@@ -0,0 +1,88 @@
+package stream
+
+// Schemas maps each EventType to a JSON Schema (draft 2020-12) describing
+// its Event envelope, including the shape of Payload. Clients that don't
+// use the Go client library in client.go can validate against these.
+var Schemas = map[EventType]string{
+	EventMoveMade: `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "move_made",
+  "type": "object",
+  "required": ["type", "game_id", "payload"],
+  "properties": {
+    "type": {"const": "move_made"},
+    "game_id": {"type": "string"},
+    "payload": {
+      "type": "object",
+      "required": ["player", "position", "board_after"],
+      "properties": {
+        "player": {"type": "string", "enum": ["X", "O"]},
+        "position": {"type": "integer", "minimum": 0},
+        "board_after": {"type": "string"}
+      }
+    }
+  }
+}`,
+
+	EventMatchboxUpdated: `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "matchbox_updated",
+  "type": "object",
+  "required": ["type", "payload"],
+  "properties": {
+    "type": {"const": "matchbox_updated"},
+    "game_id": {"type": "string"},
+    "payload": {
+      "type": "object",
+      "required": ["board_state", "bead_delta", "beads"],
+      "properties": {
+        "board_state": {"type": "string"},
+        "bead_delta": {"type": "object", "additionalProperties": {"type": "integer"}},
+        "beads": {"type": "object", "additionalProperties": {"type": "integer"}}
+      }
+    }
+  }
+}`,
+
+	EventLearningApplied: `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "learning_applied",
+  "type": "object",
+  "required": ["type", "payload"],
+  "properties": {
+    "type": {"const": "learning_applied"},
+    "game_id": {"type": "string"},
+    "payload": {
+      "type": "object",
+      "required": ["result", "matchboxes_touched"],
+      "properties": {
+        "result": {"type": "string", "enum": ["win", "loss", "draw"]},
+        "matchboxes_touched": {"type": "integer", "minimum": 0}
+      }
+    }
+  }
+}`,
+
+	EventHistorySnapshot: `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "history_snapshot",
+  "type": "object",
+  "required": ["type", "payload"],
+  "properties": {
+    "type": {"const": "history_snapshot"},
+    "payload": {
+      "type": "object",
+      "required": ["games", "total_beads", "matchbox_count", "wins", "losses", "draws", "win_rate"],
+      "properties": {
+        "games": {"type": "integer"},
+        "total_beads": {"type": "integer"},
+        "matchbox_count": {"type": "integer"},
+        "wins": {"type": "integer"},
+        "losses": {"type": "integer"},
+        "draws": {"type": "integer"},
+        "win_rate": {"type": "number"}
+      }
+    }
+  }
+}`,
+}
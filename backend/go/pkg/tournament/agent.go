@@ -0,0 +1,146 @@
+/*
+Package tournament pits Agent implementations against each other in
+round-robin or Swiss tournaments, tracking Elo ratings, so a tweak to
+Menace's WinReward/LossPenalty/InitialBeads can actually be measured
+against RandomAgent, MinimaxAgent, and (optionally) a human.
+*/
+package tournament
+
+import (
+	"bufio"
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/winzerprince/menace/backend/go/pkg/board"
+	"github.com/winzerprince/menace/backend/go/pkg/menace"
+	"github.com/winzerprince/menace/backend/go/pkg/opponent"
+)
+
+// Agent is anything that can play a full tournament: choose moves, and be
+// told the outcome afterward so agents that learn (like Menace) can.
+type Agent interface {
+	// Move returns the position `me` should play on b.
+	Move(b *board.Board, me board.Player) int
+	// Learn is called once per finished game with the result from this
+	// agent's perspective. Agents that don't learn can no-op.
+	Learn(result board.GameResult)
+}
+
+// MenaceAgent adapts *menace.Menace to Agent. The wrapped Menace keeps
+// learning across tournament games exactly as it would outside one.
+type MenaceAgent struct {
+	Menace *menace.Menace
+}
+
+func (a MenaceAgent) Move(b *board.Board, me board.Player) int {
+	return a.Menace.GetMove(b)
+}
+
+func (a MenaceAgent) Learn(result board.GameResult) {
+	a.Menace.Learn(result)
+}
+
+// RandomAgent plays uniformly random valid moves and never learns.
+type RandomAgent struct{}
+
+func (RandomAgent) Move(b *board.Board, me board.Player) int {
+	return opponent.RandomPlayer{}.Move(b, me)
+}
+
+func (RandomAgent) Learn(board.GameResult) {}
+
+// MinimaxAgent always plays a game-theoretically optimal move and never
+// learns - the ceiling every other agent is measured against.
+type MinimaxAgent struct{}
+
+func (MinimaxAgent) Move(b *board.Board, me board.Player) int {
+	return opponent.PerfectPlayer{}.Move(b, me)
+}
+
+func (MinimaxAgent) Learn(board.GameResult) {}
+
+// HumanCLIAgent prompts a human at the terminal for each move. It's meant
+// for one-off exhibition matches, not bulk tournament runs.
+type HumanCLIAgent struct {
+	reader *bufio.Reader
+}
+
+// NewHumanCLIAgent creates a HumanCLIAgent reading from stdin.
+func NewHumanCLIAgent() *HumanCLIAgent {
+	return &HumanCLIAgent{reader: bufio.NewReader(os.Stdin)}
+}
+
+func (a *HumanCLIAgent) Move(b *board.Board, me board.Player) int {
+	valid := b.GetEmptyPositions()
+	for {
+		fmt.Printf("%s\n%s to move, valid positions %v: ", b, me, valid)
+		line, err := a.reader.ReadString('\n')
+		if err != nil {
+			continue
+		}
+		pos, err := strconv.Atoi(strings.TrimSpace(line))
+		if err != nil {
+			continue
+		}
+		for _, v := range valid {
+			if v == pos {
+				return pos
+			}
+		}
+	}
+}
+
+func (a *HumanCLIAgent) Learn(result board.GameResult) {
+	fmt.Printf("Game over: %s\n", result)
+}
+
+// playGame runs agentX (playing X) against agentO (playing O) to
+// completion, calls Learn on both from their own perspective, and returns
+// the result from X's perspective plus the number of plies played.
+func playGame(agentX, agentO Agent) (board.GameResult, int) {
+	b := board.NewEmpty()
+	current := board.PlayerX
+	moves := 0
+
+	for !b.IsGameOver() {
+		var pos int
+		if current == board.PlayerX {
+			pos = agentX.Move(b, current)
+		} else {
+			pos = agentO.Move(b, current)
+		}
+
+		next, err := b.MakeMove(pos, current)
+		if err != nil {
+			break
+		}
+		b = next
+		current = current.Other()
+		moves++
+	}
+
+	resultForX := b.GetResult(board.PlayerX)
+	agentX.Learn(resultForX)
+	agentO.Learn(invertResult(resultForX))
+	return resultForX, moves
+}
+
+func invertResult(result board.GameResult) board.GameResult {
+	switch result {
+	case board.ResultWin:
+		return board.ResultLoss
+	case board.ResultLoss:
+		return board.ResultWin
+	default:
+		return result
+	}
+}
+
+// coinFlip reports whether agentA should play X this game, so repeated
+// matches between the same pair don't always start the same way.
+func coinFlip() bool {
+	return rand.Intn(2) == 0
+}
@@ -0,0 +1,44 @@
+package tournament
+
+import (
+	"math"
+
+	"github.com/winzerprince/menace/backend/go/pkg/board"
+)
+
+// DefaultElo is the rating a Participant starts a tournament with.
+const DefaultElo = 1200.0
+
+// DefaultKFactor controls how much a single result moves a rating.
+const DefaultKFactor = 32.0
+
+// expectedScore is the standard Elo expected-score formula for a playing
+// against b.
+func expectedScore(ratingA, ratingB float64) float64 {
+	return 1.0 / (1.0 + math.Pow(10, (ratingB-ratingA)/400))
+}
+
+// updateElo returns the post-game ratings for a and b given a's score
+// (1 for a win, 0.5 for a draw, 0 for a loss).
+func updateElo(ratingA, ratingB, scoreA, k float64) (newA, newB float64) {
+	ea := expectedScore(ratingA, ratingB)
+	eb := 1 - ea
+	scoreB := 1 - scoreA
+
+	newA = ratingA + k*(scoreA-ea)
+	newB = ratingB + k*(scoreB-eb)
+	return
+}
+
+// scoreFor converts a board.GameResult, as seen by the player who just
+// received it, into an Elo score.
+func scoreFor(result board.GameResult) float64 {
+	switch result {
+	case board.ResultWin:
+		return 1
+	case board.ResultDraw:
+		return 0.5
+	default:
+		return 0
+	}
+}
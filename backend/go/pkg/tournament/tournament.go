@@ -0,0 +1,211 @@
+package tournament
+
+import (
+	"sort"
+
+	"github.com/winzerprince/menace/backend/go/pkg/board"
+)
+
+// Participant is one named entrant in a Tournament.
+type Participant struct {
+	Name  string
+	Agent Agent
+	Elo   float64
+
+	games      int
+	wins       int
+	losses     int
+	draws      int
+	totalPlies int
+}
+
+// NewParticipant creates a Participant starting at DefaultElo.
+func NewParticipant(name string, agent Agent) *Participant {
+	return &Participant{Name: name, Agent: agent, Elo: DefaultElo}
+}
+
+// Stats is one participant's tournament record.
+type Stats struct {
+	Name          string  `json:"name"`
+	Games         int     `json:"games"`
+	Wins          int     `json:"wins"`
+	Losses        int     `json:"losses"`
+	Draws         int     `json:"draws"`
+	Elo           float64 `json:"elo"`
+	AvgGameLength float64 `json:"avg_game_length"`
+}
+
+// Report summarizes a completed tournament: per-participant stats, and a
+// head-to-head matrix keyed [participant][opponent] -> record against that
+// one opponent.
+type Report struct {
+	Stats      []Stats                   `json:"stats"`
+	HeadToHead map[string]map[string]H2H `json:"head_to_head"`
+}
+
+// H2H is one participant's record against a single opponent.
+type H2H struct {
+	Wins   int `json:"wins"`
+	Losses int `json:"losses"`
+	Draws  int `json:"draws"`
+}
+
+// Tournament pits a fixed set of Participants against each other.
+type Tournament struct {
+	Participants []*Participant
+	KFactor      float64
+
+	h2h map[string]map[string]H2H
+}
+
+// New creates a Tournament with the default Elo K-factor.
+func New(participants ...*Participant) *Tournament {
+	return &Tournament{
+		Participants: participants,
+		KFactor:      DefaultKFactor,
+		h2h:          make(map[string]map[string]H2H),
+	}
+}
+
+// RunRoundRobin plays gamesPerPairing games between every distinct pair of
+// participants, alternating who plays X, and returns the final Report.
+func (t *Tournament) RunRoundRobin(gamesPerPairing int) Report {
+	for i := 0; i < len(t.Participants); i++ {
+		for j := i + 1; j < len(t.Participants); j++ {
+			for g := 0; g < gamesPerPairing; g++ {
+				t.playMatch(t.Participants[i], t.Participants[j])
+			}
+		}
+	}
+	return t.report()
+}
+
+// RunSwiss plays `rounds` rounds of Swiss-system pairing: each round,
+// participants are sorted by current score (wins + 0.5*draws) and paired
+// off adjacently, preferring pairs that haven't played each other yet.
+func (t *Tournament) RunSwiss(rounds int) Report {
+	for r := 0; r < rounds; r++ {
+		order := append([]*Participant(nil), t.Participants...)
+		sort.Slice(order, func(i, j int) bool {
+			return score(order[i]) > score(order[j])
+		})
+
+		paired := make(map[*Participant]bool)
+		for i := 0; i < len(order); i++ {
+			if paired[order[i]] {
+				continue
+			}
+			opponent := nextUnplayedOpponent(order, i, paired, t.h2h)
+			if opponent == nil {
+				continue // odd one out this round, sits out
+			}
+			paired[order[i]] = true
+			paired[opponent] = true
+			t.playMatch(order[i], opponent)
+		}
+	}
+	return t.report()
+}
+
+// score is a participant's Swiss tournament score: 1 per win, 0.5 per draw.
+func score(p *Participant) float64 {
+	return float64(p.wins) + 0.5*float64(p.draws)
+}
+
+// nextUnplayedOpponent finds the closest-ranked, not-yet-paired-this-round
+// participant that order[i] hasn't already faced, falling back to any
+// not-yet-paired participant if everyone nearby has already been played.
+func nextUnplayedOpponent(order []*Participant, i int, paired map[*Participant]bool, h2h map[string]map[string]H2H) *Participant {
+	var fallback *Participant
+	for j := i + 1; j < len(order); j++ {
+		if paired[order[j]] {
+			continue
+		}
+		if fallback == nil {
+			fallback = order[j]
+		}
+		if _, played := h2h[order[i].Name][order[j].Name]; !played {
+			return order[j]
+		}
+	}
+	return fallback
+}
+
+// playMatch plays one game between a and b, updates both participants'
+// records and Elo ratings, and records the result in the head-to-head
+// matrix.
+func (t *Tournament) playMatch(a, b *Participant) {
+	var result board.GameResult
+	var plies int
+	var aWasX bool
+
+	if coinFlip() {
+		result, plies = playGame(a.Agent, b.Agent)
+		aWasX = true
+	} else {
+		result, plies = playGame(b.Agent, a.Agent)
+		aWasX = false
+	}
+
+	resultForA := result
+	if !aWasX {
+		resultForA = invertResult(result)
+	}
+	resultForB := invertResult(resultForA)
+
+	a.record(resultForA, plies)
+	b.record(resultForB, plies)
+	t.recordH2H(a.Name, b.Name, resultForA)
+	t.recordH2H(b.Name, a.Name, resultForB)
+
+	a.Elo, b.Elo = updateElo(a.Elo, b.Elo, scoreFor(resultForA), t.KFactor)
+}
+
+func (p *Participant) record(result board.GameResult, plies int) {
+	p.games++
+	p.totalPlies += plies
+	switch result {
+	case board.ResultWin:
+		p.wins++
+	case board.ResultLoss:
+		p.losses++
+	case board.ResultDraw:
+		p.draws++
+	}
+}
+
+func (t *Tournament) recordH2H(name, opponent string, result board.GameResult) {
+	if t.h2h[name] == nil {
+		t.h2h[name] = make(map[string]H2H)
+	}
+	record := t.h2h[name][opponent]
+	switch result {
+	case board.ResultWin:
+		record.Wins++
+	case board.ResultLoss:
+		record.Losses++
+	case board.ResultDraw:
+		record.Draws++
+	}
+	t.h2h[name][opponent] = record
+}
+
+func (t *Tournament) report() Report {
+	stats := make([]Stats, len(t.Participants))
+	for i, p := range t.Participants {
+		avg := 0.0
+		if p.games > 0 {
+			avg = float64(p.totalPlies) / float64(p.games)
+		}
+		stats[i] = Stats{
+			Name:          p.Name,
+			Games:         p.games,
+			Wins:          p.wins,
+			Losses:        p.losses,
+			Draws:         p.draws,
+			Elo:           p.Elo,
+			AvgGameLength: avg,
+		}
+	}
+	return Report{Stats: stats, HeadToHead: t.h2h}
+}